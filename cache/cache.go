@@ -0,0 +1,315 @@
+// Package cache provides a pluggable response cache for the HERE API clients.
+//
+// The caching middleware plugs into the WithMiddleware option of the HERE API clients, and the caching transport can
+// be used with the *http.Client accepted by routingv7. Responses are keyed by a canonical fingerprint of the
+// request. Since the clients encode identical request structs into identical HTTP requests, repeated calls with
+// identical inputs, e.g. geocoding the same depot address, are served from the cache.
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"sync/atomic"
+
+	"go.einride.tech/here/internal/core"
+)
+
+// Cache stores responses by key. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, and false if there is none or it has expired.
+	Get(key string) ([]byte, bool, error)
+	// Set stores the value for key.
+	Set(key string, value []byte) error
+}
+
+// HTTPClient is the interface used by the HERE API clients to send requests.
+type HTTPClient = core.HTTPClient
+
+// Middleware wraps the HTTPClient of a HERE API client, as accepted by its WithMiddleware option.
+type Middleware = core.Middleware
+
+// Result is the outcome of looking up a request in the cache.
+type Result int
+
+const (
+	// ResultBypass means that the request was not cacheable and was sent without looking up the cache.
+	ResultBypass Result = iota
+	// ResultHit means that the response was served from the cache.
+	ResultHit
+	// ResultMiss means that the response was not in the cache and the request was sent.
+	ResultMiss
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultBypass:
+		return "bypass"
+	case ResultHit:
+		return "hit"
+	case ResultMiss:
+		return "miss"
+	default:
+		return "invalid"
+	}
+}
+
+// Event describes the outcome of a request sent through a caching Client.
+type Event struct {
+	// Request that was looked up.
+	Request *http.Request
+	// Key of the request in the cache. Empty when the request was bypassed.
+	Key string
+	// Result of the lookup.
+	Result Result
+	// Err is the error returned by the cache, if any. Cache errors are not returned to the caller, the request is
+	// sent instead.
+	Err error
+}
+
+// Stats counts the outcomes of requests sent through a caching Client.
+type Stats struct {
+	Hits     int64
+	Misses   int64
+	Bypasses int64
+}
+
+// Config configures which responses are cached and where.
+type Config struct {
+	// Cache to store responses in. Required.
+	Cache Cache
+	// AllowTimeDependent allows caching responses to time-dependent requests, see IsTimeDependent. Such responses
+	// depend on the traffic at the departure or arrival time, and are not cached by default.
+	AllowTimeDependent bool
+	// Cacheable reports whether the response to a request can be cached.
+	// If not specified defaults to IsCacheable.
+	Cacheable func(req *http.Request) bool
+	// Observer is called with the outcome of every request, e.g. to export cache hit and miss metrics.
+	Observer func(Event)
+}
+
+// Client is an HTTPClient that serves responses from a cache.
+type Client struct {
+	next     HTTPClient
+	config   Config
+	hits     atomic.Int64
+	misses   atomic.Int64
+	bypasses atomic.Int64
+}
+
+// NewHTTPClient returns an HTTPClient which caches the responses to requests sent through next according to config.
+// If next is nil http.DefaultClient is used.
+func NewHTTPClient(next HTTPClient, config Config) *Client {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	if config.Cacheable == nil {
+		config.Cacheable = IsCacheable
+	}
+	return &Client{next: next, config: config}
+}
+
+// NewMiddleware returns a Middleware which caches responses according to config. Use NewHTTPClient instead to read
+// the Stats of the cache, or observe it with Config.Observer.
+func NewMiddleware(config Config) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return NewHTTPClient(next, config)
+	}
+}
+
+// NewTransport returns a RoundTripper which caches the responses to requests sent through next according to
+// config. If next is nil http.DefaultTransport is used.
+func NewTransport(next http.RoundTripper, config Config) http.RoundTripper {
+	return core.NewTransport(next, NewMiddleware(config))
+}
+
+// Stats returns the outcomes of the requests sent through the client so far.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Bypasses: c.bypasses.Load(),
+	}
+}
+
+// Do returns the cached response to the request if there is one. Otherwise it sends the request, and caches the
+// response if it is successful.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if !c.config.Cacheable(req) || (!c.config.AllowTimeDependent && IsTimeDependent(req)) {
+		c.observe(Event{Request: req, Result: ResultBypass})
+		return c.next.Do(req)
+	}
+	key, err := Fingerprint(req)
+	if err != nil {
+		c.observe(Event{Request: req, Result: ResultBypass, Err: err})
+		return c.next.Do(req)
+	}
+	value, ok, cacheErr := c.config.Cache.Get(key)
+	if ok && cacheErr == nil {
+		resp, err := decodeResponse(value, req)
+		if err == nil {
+			c.observe(Event{Request: req, Key: key, Result: ResultHit})
+			return resp, nil
+		}
+		cacheErr = err
+	}
+	resp, err := c.next.Do(req)
+	if err != nil {
+		c.observe(Event{Request: req, Key: key, Result: ResultMiss, Err: cacheErr})
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		value, err := encodeResponse(resp)
+		if err == nil {
+			err = c.config.Cache.Set(key, value)
+		}
+		if cacheErr == nil {
+			cacheErr = err
+		}
+	}
+	c.observe(Event{Request: req, Key: key, Result: ResultMiss, Err: cacheErr})
+	return resp, nil
+}
+
+func (c *Client) observe(event Event) {
+	switch event.Result {
+	case ResultHit:
+		c.hits.Add(1)
+	case ResultMiss:
+		c.misses.Add(1)
+	case ResultBypass:
+		c.bypasses.Add(1)
+	}
+	if c.config.Observer != nil {
+		c.config.Observer(event)
+	}
+}
+
+// IsCacheable reports whether the request calculates routes, a synchronous matrix or geocodes, which are the
+// requests of the HERE APIs supported by this module whose responses only depend on the request.
+// Asynchronous matrix calculations and batch geocoding jobs are not cacheable.
+func IsCacheable(req *http.Request) bool {
+	switch path.Base(req.URL.Path) {
+	case "routes", "import", "calculateroute.json", "calculatematrix.json", "getroute.json", "geocode", "revgeocode":
+		return true
+	case "matrix":
+		return req.Method == http.MethodPost && req.URL.Query().Get("async") != "true"
+	default:
+		return false
+	}
+}
+
+// timeParameters are the query parameters of the HERE APIs holding a departure or arrival time.
+var timeParameters = []string{"departureTime", "arrivalTime", "departure", "arrival"}
+
+// IsTimeDependent reports whether the request has a departure or arrival time other than "any", either as a query
+// parameter or in the body of a matrix request. Routing and matrix requests without a departure or arrival time are
+// time-dependent as well, since the HERE APIs then depart now and use live traffic. Only a departure time of "any"
+// makes them time-independent.
+func IsTimeDependent(req *http.Request) bool {
+	query := req.URL.Query()
+	for _, parameter := range timeParameters {
+		if value := query.Get(parameter); value != "" && value != "any" {
+			return true
+		}
+	}
+	if query.Get("departureTime") == "any" || query.Get("departure") == "any" {
+		return false
+	}
+	body, err := core.ReadBody(req)
+	if err == nil && len(body) > 0 {
+		var matrix struct {
+			DepartureTime string `json:"departureTime"`
+		}
+		if err := json.Unmarshal(body, &matrix); err == nil && matrix.DepartureTime != "" {
+			return matrix.DepartureTime != "any"
+		}
+	}
+	return isRouting(req)
+}
+
+// isRouting reports whether the request calculates routes or a matrix, whose responses depend on traffic.
+func isRouting(req *http.Request) bool {
+	switch path.Base(req.URL.Path) {
+	case "routes", "import", "matrix", "calculateroute.json", "calculatematrix.json", "getroute.json":
+		return true
+	default:
+		return false
+	}
+}
+
+// Fingerprint returns the canonical key of a request: a hash of its method, host, path, query parameters in
+// sorted order and body. The apiKey query parameter and the headers of the request, including credentials, are
+// not part of the key.
+func Fingerprint(req *http.Request) (string, error) {
+	body, err := core.ReadBody(req)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	query.Del("apiKey")
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.Method+"\n"+req.URL.Host+"\n"+req.URL.EscapedPath()+"\n")
+	for _, key := range keys {
+		for _, value := range query[key] {
+			_, _ = io.WriteString(h, url.QueryEscape(key)+"="+url.QueryEscape(value)+"\n")
+		}
+	}
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// encodeResponse returns the wire format of resp, and replaces its consumed body with a copy.
+func encodeResponse(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	// Only keep the headers describing the body, the cached response is served with a different status line.
+	cached := http.Response{
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		StatusCode:    resp.StatusCode,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		cached.Header.Set("Content-Type", contentType)
+	}
+	var buf bytes.Buffer
+	if err := cached.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeResponse(value []byte, req *http.Request) (*http.Response, error) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(value)), req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
@@ -0,0 +1,190 @@
+package cache_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.einride.tech/here/cache"
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+type CountingMock struct {
+	mu       sync.Mutex
+	requests int
+	body     string
+}
+
+func (c *CountingMock) Do(_ *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+func TestClient_Geocoding(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mock := CountingMock{body: `{"items":[{"title":"Regeringsgatan 65, 111 56 Stockholm, Sverige"}]}`}
+	var events []cache.Event
+	httpClient := cache.NewHTTPClient(&mock, cache.Config{
+		Cache: cache.NewMemory(cache.MemoryConfig{}),
+		Observer: func(event cache.Event) {
+			events = append(events, event)
+		},
+	})
+	client := geocodingsearchv7.NewClient(httpClient)
+	q := "Regeringsgatan 65, Stockholm"
+	for i := 0; i < 3; i++ {
+		resp, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+		assert.Equal(t, resp.Items[0].Title, "Regeringsgatan 65, 111 56 Stockholm, Sverige")
+	}
+	assert.Equal(t, mock.requests, 1)
+	assert.DeepEqual(t, httpClient.Stats(), cache.Stats{Hits: 2, Misses: 1})
+	assert.Equal(t, len(events), 3)
+	assert.Equal(t, events[0].Result, cache.ResultMiss)
+	assert.Equal(t, events[1].Result, cache.ResultHit)
+	assert.Equal(t, events[1].Key, events[0].Key)
+}
+
+func TestNewMiddleware(t *testing.T) {
+	t.Parallel()
+	mock := CountingMock{body: `{"items":[{"title":"Regeringsgatan 65, 111 56 Stockholm, Sverige"}]}`}
+	var results []cache.Result
+	client := geocodingsearchv7.NewClient(&mock, geocodingsearchv7.WithMiddleware(cache.NewMiddleware(cache.Config{
+		Cache: cache.NewMemory(cache.MemoryConfig{}),
+		Observer: func(event cache.Event) {
+			results = append(results, event.Result)
+		},
+	})))
+	q := "Regeringsgatan 65, Stockholm"
+	for i := 0; i < 2; i++ {
+		_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, mock.requests, 1)
+	assert.DeepEqual(t, results, []cache.Result{cache.ResultMiss, cache.ResultHit})
+}
+
+func TestClient_Routes_TimeDependent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	request := &routingv8.RoutesRequest{
+		Origin:        routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767},
+		Destination:   routingv8.GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+		TransportMode: routingv8.TransportModeTruck,
+		DepartureTime: "2024-01-02T08:00:00+01:00",
+	}
+	for _, tt := range []struct {
+		name               string
+		departureTime      string
+		allowTimeDependent bool
+		expectedRequests   int
+		expectedStats      cache.Stats
+	}{
+		{
+			name:             "without departure time",
+			expectedRequests: 2,
+			expectedStats:    cache.Stats{Bypasses: 2},
+		},
+		{
+			name:             "with any departure time",
+			departureTime:    routingv8.DepartureTimeAny,
+			expectedRequests: 1,
+			expectedStats:    cache.Stats{Hits: 1, Misses: 1},
+		},
+		{
+			name:             "with departure time",
+			departureTime:    "2024-01-02T08:00:00+01:00",
+			expectedRequests: 2,
+			expectedStats:    cache.Stats{Bypasses: 2},
+		},
+		{
+			name:               "with departure time allowed",
+			departureTime:      "2024-01-02T08:00:00+01:00",
+			allowTimeDependent: true,
+			expectedRequests:   1,
+			expectedStats:      cache.Stats{Hits: 1, Misses: 1},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := CountingMock{body: `{"routes":[{"id":"route"}]}`}
+			httpClient := cache.NewHTTPClient(&mock, cache.Config{
+				Cache:              cache.NewMemory(cache.MemoryConfig{}),
+				AllowTimeDependent: tt.allowTimeDependent,
+			})
+			client := routingv8.NewClient(httpClient)
+			request := *request
+			request.DepartureTime = tt.departureTime
+			for i := 0; i < 2; i++ {
+				resp, err := client.Routing.Routes(ctx, &request)
+				assert.NilError(t, err)
+				assert.Equal(t, resp.Routes[0].ID, "route")
+			}
+			assert.Equal(t, mock.requests, tt.expectedRequests)
+			assert.DeepEqual(t, httpClient.Stats(), tt.expectedStats)
+		})
+	}
+}
+
+func TestClient_Matrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mock := CountingMock{body: `{"matrixId":"id","matrix":{"numOrigins":1,"numDestinations":1}}`}
+	httpClient := cache.NewHTTPClient(&mock, cache.Config{Cache: cache.NewMemory(cache.MemoryConfig{})})
+	client := routingv8.NewClient(httpClient)
+	request := func(lat float64) *routingv8.CalculateMatrixRequest {
+		return &routingv8.CalculateMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:          []*routingv8.GeoWaypoint{{Lat: lat, Long: 11.949767}},
+				Destinations:     []*routingv8.GeoWaypoint{{Lat: 59.337492, Long: 18.063672}},
+				RegionDefinition: routingv8.RegionDefinition{Type: routingv8.RegionTypeWorld},
+				DepartureTime:    routingv8.DepartureTimeAny,
+			},
+		}
+	}
+	for _, lat := range []float64{57.707752, 57.707752, 57.8} {
+		_, err := client.Matrix.CalculateMatrix(ctx, request(lat))
+		assert.NilError(t, err)
+	}
+	assert.DeepEqual(t, httpClient.Stats(), cache.Stats{Hits: 1, Misses: 2})
+	asyncRequest := request(57.707752)
+	asyncRequest.Async = true
+	_, err := client.Matrix.CalculateMatrix(ctx, asyncRequest)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, httpClient.Stats(), cache.Stats{Hits: 1, Misses: 2, Bypasses: 1})
+	// Without a departure time, the matrix is calculated for the current traffic.
+	liveRequest := request(57.707752)
+	liveRequest.Body.DepartureTime = ""
+	_, err = client.Matrix.CalculateMatrix(ctx, liveRequest)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, httpClient.Stats(), cache.Stats{Hits: 1, Misses: 2, Bypasses: 2})
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+	fingerprint := func(rawURL string) string {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+		assert.NilError(t, err)
+		key, err := cache.Fingerprint(req)
+		assert.NilError(t, err)
+		return key
+	}
+	base := fingerprint("https://geocode.search.hereapi.com/v1/geocode?q=Stockholm&lang=sv")
+	// Query parameter order and API keys do not matter.
+	assert.Equal(t, fingerprint("https://geocode.search.hereapi.com/v1/geocode?lang=sv&q=Stockholm&apiKey=k"), base)
+	assert.Assert(t, fingerprint("https://geocode.search.hereapi.com/v1/geocode?q=Stockholm&lang=en") != base)
+	assert.Assert(t, fingerprint("https://revgeocode.search.hereapi.com/v1/geocode?q=Stockholm&lang=sv") != base)
+}
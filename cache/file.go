@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File is a Cache storing one file per entry in a directory, so that entries survive restarts and can be shared
+// between processes.
+type File struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewFile returns a Cache storing entries in dir, which is created if it does not exist.
+// Entries expire ttl after they were stored. If ttl is zero entries never expire.
+func NewFile(dir string, ttl time.Duration) (*File, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cache: create directory: %w", err)
+	}
+	return &File{dir: dir, ttl: ttl, now: time.Now}, nil
+}
+
+// Get implements Cache. Expired entries are removed.
+func (f *File) Get(key string) ([]byte, bool, error) {
+	name, err := f.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+	info, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if f.ttl > 0 && !f.now().Before(info.ModTime().Add(f.ttl)) {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	value, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements Cache. The entry is written to a temporary file first, so that concurrent readers never observe a
+// partially written entry.
+func (f *File) Set(key string, value []byte) (err error) {
+	name, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// path returns the file of an entry. Keys are used as file names and must not contain path separators.
+func (f *File) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key[0] == '.' {
+		return "", fmt.Errorf("cache: invalid key %q", key)
+	}
+	return filepath.Join(f.dir, key), nil
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+	f, err := NewFile(t.TempDir(), time.Hour)
+	assert.NilError(t, err)
+	_, ok, err := f.Get("a")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+	assert.NilError(t, f.Set("a", []byte("1")))
+	value, ok, err := f.Get("a")
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, string(value), "1")
+	// Entries survive reopening the directory.
+	reopened, err := NewFile(f.dir, time.Hour)
+	assert.NilError(t, err)
+	value, ok, err = reopened.Get("a")
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, string(value), "1")
+	// Expired entries are removed.
+	reopened.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, ok, err = reopened.Get("a")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+	_, ok, err = f.Get("a")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+}
+
+func TestFile_InvalidKey(t *testing.T) {
+	t.Parallel()
+	f, err := NewFile(t.TempDir(), 0)
+	assert.NilError(t, err)
+	for _, key := range []string{"", "../a", "a/b", ".tmp-a"} {
+		assert.ErrorContains(t, f.Set(key, nil), "invalid key")
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultMaxEntries is the capacity of a Memory cache when MemoryConfig.MaxEntries is not specified.
+const DefaultMaxEntries = 1000
+
+// MemoryConfig configures a Memory cache.
+type MemoryConfig struct {
+	// MaxEntries is the number of entries after which the least recently used entry is evicted.
+	// If not specified defaults to DefaultMaxEntries.
+	MaxEntries int
+	// TTL is the time after which an entry expires. If not specified entries only expire when evicted.
+	TTL time.Duration
+}
+
+// Memory is an in-memory Cache which evicts the least recently used entries.
+type Memory struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	config  MemoryConfig
+	entries map[string]*list.Element
+	// recency of the entries, from most to least recently used.
+	recency *list.List
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemory returns an in-memory Cache.
+func NewMemory(config MemoryConfig) *Memory {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultMaxEntries
+	}
+	return &Memory{
+		now:     time.Now,
+		config:  config,
+		entries: make(map[string]*list.Element),
+		recency: list.New(),
+	}
+}
+
+// Get implements Cache.
+func (m *Memory) Get(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && !m.now().Before(entry.expires) {
+		m.remove(element)
+		return nil, false, nil
+	}
+	m.recency.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set implements Cache.
+func (m *Memory) Set(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var expires time.Time
+	if m.config.TTL > 0 {
+		expires = m.now().Add(m.config.TTL)
+	}
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value, entry.expires = value, expires
+		m.recency.MoveToFront(element)
+		return nil
+	}
+	m.entries[key] = m.recency.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	for m.recency.Len() > m.config.MaxEntries {
+		m.remove(m.recency.Back())
+	}
+	return nil
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet evicted.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.recency.Len()
+}
+
+func (m *Memory) remove(element *list.Element) {
+	m.recency.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)
+	m := NewMemory(MemoryConfig{MaxEntries: 2, TTL: time.Minute})
+	m.now = func() time.Time { return now }
+	get := func(key string) string {
+		value, ok, err := m.Get(key)
+		assert.NilError(t, err)
+		if !ok {
+			return ""
+		}
+		return string(value)
+	}
+	assert.NilError(t, m.Set("a", []byte("1")))
+	assert.NilError(t, m.Set("b", []byte("2")))
+	assert.Equal(t, get("a"), "1")
+	// Evicts b, the least recently used entry.
+	assert.NilError(t, m.Set("c", []byte("3")))
+	assert.Equal(t, m.Len(), 2)
+	assert.Equal(t, get("b"), "")
+	assert.Equal(t, get("a"), "1")
+	assert.Equal(t, get("c"), "3")
+	// Overwriting refreshes the TTL.
+	now = now.Add(30 * time.Second)
+	assert.NilError(t, m.Set("a", []byte("4")))
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, get("c"), "")
+	assert.Equal(t, get("a"), "4")
+	assert.Equal(t, m.Len(), 1)
+}
@@ -0,0 +1,382 @@
+// Package cassette records HERE API traffic to a file and replays it, so that tests can run without network access.
+//
+// The cassette HTTP client plugs into the HTTPClient interface of routingv8 and geocodingsearchv7, the cassette
+// middleware into their WithMiddleware option, and the cassette transport can be used with the *http.Client accepted
+// by routingv7. Record a cassette once against the real API:
+//
+//	client, err := cassette.NewHTTPClient(
+//		routingv8.NewAPIKeyHTTPClient(apiKey, nil),
+//		cassette.Config{Path: "testdata/routes.json", Mode: cassette.ModeRecord},
+//	)
+//
+// and replay it in CI with the default ModeReplay, where no requests are sent. The apiKey query parameter and
+// credential headers are redacted before the cassette is written.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"go.einride.tech/here/internal/core"
+)
+
+// HTTPClient is the interface used by the HERE API clients to send requests.
+type HTTPClient = core.HTTPClient
+
+// Middleware wraps the HTTPClient of a HERE API client, as accepted by its WithMiddleware option.
+type Middleware = core.Middleware
+
+// Mode selects whether a cassette is recorded or replayed.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette, and fails requests that were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records them to the cassette, replacing any previous recording.
+	ModeRecord
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	default:
+		return "invalid"
+	}
+}
+
+// Redacted replaces the values of redacted query parameters and headers in a cassette.
+const Redacted = "REDACTED"
+
+// Config configures a cassette.
+type Config struct {
+	// Path of the cassette file. Required.
+	Path string
+	// Mode of the cassette. Defaults to ModeReplay.
+	Mode Mode
+	// RedactQuery lists query parameters to redact in addition to apiKey.
+	RedactQuery []string
+	// RedactHeaders lists headers to redact in addition to Authorization, Proxy-Authorization and X-Api-Key.
+	RedactHeaders []string
+}
+
+// Cassette is the file format of a recording.
+type Cassette struct {
+	// Interactions in the order they were recorded.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Body of a recorded request or response. Bodies that are valid UTF-8 are stored as text, other bodies, e.g.
+// zipped batch geocoder results, are stored base64 encoded.
+type Body struct {
+	Text   string `json:"body,omitempty"`
+	Base64 []byte `json:"bodyBase64,omitempty"`
+}
+
+func newBody(data []byte) Body {
+	if utf8.Valid(data) {
+		return Body{Text: string(data)}
+	}
+	return Body{Base64: data}
+}
+
+// Bytes returns the content of the body.
+func (b Body) Bytes() []byte {
+	if b.Base64 != nil {
+		return b.Base64
+	}
+	return []byte(b.Text)
+}
+
+// UnmatchedRequestError is returned in ModeReplay for requests that were not recorded.
+type UnmatchedRequestError struct {
+	// Method of the request.
+	Method string
+	// URL of the request, with credentials redacted.
+	URL string
+	// Path of the cassette.
+	Path string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("cassette: no interaction in %s matches %s %s", e.Path, e.Method, e.URL)
+}
+
+// Client is an HTTPClient that records or replays a cassette.
+type Client struct {
+	next HTTPClient
+	*recording
+}
+
+// recording is a cassette being recorded or replayed, shared by the clients of a middleware.
+type recording struct {
+	config Config
+
+	mu       sync.Mutex
+	cassette Cassette
+	// played counts the replayed interactions per match key.
+	played map[string]int
+}
+
+func newRecording(config Config) (*recording, error) {
+	if config.Path == "" {
+		return nil, errors.New("cassette: path is required")
+	}
+	r := &recording{config: config, played: make(map[string]int)}
+	switch config.Mode {
+	case ModeReplay:
+		data, err := os.ReadFile(config.Path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("cassette: decode %s: %w", config.Path, err)
+		}
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("cassette: invalid mode %d", config.Mode)
+	}
+	return r, nil
+}
+
+// NewHTTPClient returns an HTTPClient which records the requests sent through next to the cassette, or replays
+// them from the cassette, according to config. In ModeReplay the cassette must exist, and next is not used.
+// If next is nil http.DefaultClient is used.
+func NewHTTPClient(next HTTPClient, config Config) (*Client, error) {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	r, err := newRecording(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{next: next, recording: r}, nil
+}
+
+// NewMiddleware returns a Middleware which records or replays a cassette according to config. In ModeReplay the
+// cassette must exist. All clients wrapped by the middleware share the cassette.
+func NewMiddleware(config Config) (Middleware, error) {
+	r, err := newRecording(config)
+	if err != nil {
+		return nil, err
+	}
+	return func(next HTTPClient) HTTPClient {
+		return &Client{next: next, recording: r}
+	}, nil
+}
+
+// NewTransport returns a RoundTripper which records or replays a cassette according to config.
+// If next is nil http.DefaultTransport is used.
+func NewTransport(next http.RoundTripper, config Config) (http.RoundTripper, error) {
+	middleware, err := NewMiddleware(config)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewTransport(next, middleware), nil
+}
+
+// Do records or replays the request.
+//
+// In ModeReplay requests are matched on method, path, query parameters in sorted order and body, with JSON bodies
+// compared regardless of formatting. Identical requests are replayed in the order they were recorded, and the last
+// recording is repeated once they are exhausted, e.g. for a request that was retried more often than recorded.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	body, err := core.ReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if c.config.Mode == ModeRecord {
+		return c.record(req, body)
+	}
+	return c.replay(req, body)
+}
+
+func (c *Client) replay(req *http.Request, body []byte) (*http.Response, error) {
+	key := c.matchKey(req.Method, req.URL, body)
+	c.mu.Lock()
+	var matches []*Interaction
+	for i := range c.cassette.Interactions {
+		interaction := &c.cassette.Interactions[i]
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if c.matchKey(interaction.Request.Method, u, interaction.Request.Bytes()) == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		c.mu.Unlock()
+		return nil, &UnmatchedRequestError{Method: req.Method, URL: c.redactURL(req.URL), Path: c.config.Path}
+	}
+	interaction := matches[min(c.played[key], len(matches)-1)]
+	c.played[key]++
+	c.mu.Unlock()
+	data := interaction.Response.Bytes()
+	statusCode := interaction.Response.StatusCode
+	return &http.Response{
+		Status:        strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Client) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := c.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    c.redactURL(req.URL),
+			Header: c.redactHeader(req.Header),
+			Body:   newBody(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     c.redactHeader(resp.Header),
+			Body:       newBody(data),
+		},
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the cassette to a temporary file first, so that an interrupted recording never leaves a partially
+// written cassette. Must be called with mu held.
+func (c *Client) save() (err error) {
+	data, err := json.MarshalIndent(&c.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(c.config.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("cassette: create directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".cassette-*")
+	if err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.config.Path); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// matchKey returns the key requests are matched on: method, path, query parameters in sorted order without the
+// redacted ones, and body.
+func (c *Client) matchKey(method string, u *url.URL, body []byte) string {
+	query := u.Query()
+	for _, key := range c.redactedQuery() {
+		query.Del(key)
+	}
+	return method + " " + u.EscapedPath() + "?" + query.Encode() + "\n" + string(normalizeBody(body))
+}
+
+// normalizeBody returns JSON bodies in compact form with sorted object keys, and other bodies unchanged.
+func normalizeBody(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return normalized
+}
+
+func (c *Client) redactedQuery() []string {
+	return append([]string{"apiKey"}, c.config.RedactQuery...)
+}
+
+func (c *Client) redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	for _, key := range c.redactedQuery() {
+		if query.Has(key) {
+			query.Set(key, Redacted)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func (c *Client) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	keys := append([]string{"Authorization", "Proxy-Authorization", "X-Api-Key"}, c.config.RedactHeaders...)
+	for _, key := range keys {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, Redacted)
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,275 @@
+package cassette_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/cassette"
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/heretest"
+	"go.einride.tech/here/routingv7"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestClient_RoutingV8(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "routingv8.json")
+	server := heretest.NewServer(heretest.Config{APIKey: "secret", PendingPolls: 1})
+	routesRequest := &routingv8.RoutesRequest{
+		Origin:        routingv8.GeoWaypoint{Lat: 57.70775, Long: 11.94977},
+		Destination:   routingv8.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+		TransportMode: routingv8.TransportModeTruck,
+	}
+	matrixRequest := &routingv8.CalculateMatrixRequest{
+		Body: &routingv8.CalculateMatrixBody{
+			Origins:          []*routingv8.GeoWaypoint{&routesRequest.Origin, &routesRequest.Destination},
+			RegionDefinition: routingv8.RegionDefinition{Type: routingv8.RegionTypeWorld},
+		},
+	}
+	run := func(client *routingv8.Client) (*routingv8.RoutesResponse, *routingv8.CalculateMatrixResponse) {
+		ctx := context.Background()
+		routes, err := client.Routing.Routes(ctx, routesRequest)
+		assert.NilError(t, err)
+		status, err := client.Matrix.CalculateMatrixAsync(ctx, matrixRequest)
+		assert.NilError(t, err)
+		matrix, err := client.Matrix.WaitForMatrix(ctx, &routingv8.WaitForMatrixRequest{
+			MatrixID:     status.MatrixID,
+			PollInterval: time.Millisecond,
+		})
+		assert.NilError(t, err)
+		return routes, matrix
+	}
+	// The cassette is the transport of the API key client, so that the API key is part of the recorded requests.
+	recorder, err := cassette.NewTransport(nil, cassette.Config{Path: path, Mode: cassette.ModeRecord})
+	assert.NilError(t, err)
+	recordedRoutes, recordedMatrix := run(routingv8.NewClient(
+		routingv8.NewAPIKeyHTTPClient("secret", recorder),
+		routingv8.WithBaseURL(server.BaseURL()),
+	))
+	server.Close()
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(data), "secret"))
+	assert.Assert(t, strings.Contains(string(data), "apiKey="+cassette.Redacted))
+	// The server is closed, so all responses are replayed from the cassette.
+	player, err := cassette.NewTransport(nil, cassette.Config{Path: path})
+	assert.NilError(t, err)
+	replayedRoutes, replayedMatrix := run(routingv8.NewClient(
+		routingv8.NewAPIKeyHTTPClient("other", player),
+		routingv8.WithBaseURL(server.BaseURL()),
+	))
+	assert.DeepEqual(t, replayedRoutes, recordedRoutes)
+	assert.DeepEqual(t, replayedMatrix, recordedMatrix)
+}
+
+func TestClient_GeocodingSearchV7(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "geocodingsearchv7.json")
+	server := heretest.NewServer(heretest.Config{})
+	run := func(client *geocodingsearchv7.Client) (*geocodingsearchv7.GeocodingResponse, []byte) {
+		ctx := context.Background()
+		q := "Regeringsgatan 65, Stockholm"
+		geocoding, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+		upload, err := client.BatchGeocoding.BatchGeocoderUpload(ctx, &geocodingsearchv7.BatchGeocoderUploadRequest{
+			Queries: []*geocodingsearchv7.QueryString{{RecID: "1", Query: q, Country: "SWE"}},
+		})
+		assert.NilError(t, err)
+		var result bytes.Buffer
+		assert.NilError(t, client.BatchGeocoding.BatchGeocoderDownload(
+			ctx,
+			&geocodingsearchv7.BatchGeocoderDownloadRequest{RequestID: upload.Response.MetaInfo.RequestID},
+			&result,
+		))
+		return geocoding, result.Bytes()
+	}
+	recorder, err := cassette.NewHTTPClient(nil, cassette.Config{Path: path, Mode: cassette.ModeRecord})
+	assert.NilError(t, err)
+	recordedGeocoding, recordedResult := run(
+		geocodingsearchv7.NewClient(recorder, geocodingsearchv7.WithBaseURL(server.BaseURL())),
+	)
+	server.Close()
+	player, err := cassette.NewHTTPClient(nil, cassette.Config{Path: path})
+	assert.NilError(t, err)
+	replayedGeocoding, replayedResult := run(
+		geocodingsearchv7.NewClient(player, geocodingsearchv7.WithBaseURL(server.BaseURL())),
+	)
+	assert.DeepEqual(t, replayedGeocoding, recordedGeocoding)
+	// The zipped batch result is binary, and survives the round trip through the cassette.
+	assert.DeepEqual(t, replayedResult, recordedResult)
+}
+
+func TestTransport_RoutingV7(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "routingv7.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"response":{"metaInfo":{"requestId":"`+r.URL.Query().Get("waypoint0")+`"}}}`)
+	}))
+	baseURL, err := url.Parse(server.URL)
+	assert.NilError(t, err)
+	request := &routingv7.CalculateRouteRequest{
+		Waypoints: []routingv7.WaypointParameter{
+			&routingv7.GeoWaypoint{Lat: 57.70775, Long: 11.94977},
+			&routingv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+		},
+		Mode: routingv7.RoutingMode{Type: routingv7.RouteTypeFastest},
+	}
+	recorder, err := cassette.NewTransport(nil, cassette.Config{Path: path, Mode: cassette.ModeRecord})
+	assert.NilError(t, err)
+	recorded, err := routingv7.New(&http.Client{Transport: recorder}, routingv7.WithBaseURL(baseURL)).
+		Route.CalculateRoute(context.Background(), request)
+	assert.NilError(t, err)
+	server.Close()
+	player, err := cassette.NewTransport(nil, cassette.Config{Path: path})
+	assert.NilError(t, err)
+	replayed, err := routingv7.New(&http.Client{Transport: player}, routingv7.WithBaseURL(baseURL)).
+		Route.CalculateRoute(context.Background(), request)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, replayed, recorded)
+	assert.Equal(t, replayed.MetaInfo.RequestID, "geo!57.70775000,11.94977000")
+}
+
+func TestClient_Replay(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	assert.NilError(t, os.WriteFile(path, []byte(`{
+  "interactions": [
+    {
+      "request": {
+        "method": "POST",
+        "url": "https://matrix.router.hereapi.com/v8/matrix?apiKey=REDACTED&async=false",
+        "body": "{\"origins\": [{\"lat\": 1, \"lng\": 2}], \"regionDefinition\": {\"type\": \"world\"}}"
+      },
+      "response": {"statusCode": 429, "body": "first"}
+    },
+    {
+      "request": {
+        "method": "POST",
+        "url": "https://matrix.router.hereapi.com/v8/matrix?async=false&apiKey=REDACTED",
+        "body": "{\"origins\": [{\"lat\": 1, \"lng\": 2}], \"regionDefinition\": {\"type\": \"world\"}}"
+      },
+      "response": {"statusCode": 200, "body": "second"}
+    }
+  ]
+}`), 0o600))
+	client, err := cassette.NewHTTPClient(nil, cassette.Config{Path: path})
+	assert.NilError(t, err)
+	do := func(query, body string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(
+			context.Background(),
+			http.MethodPost,
+			"https://matrix.router.hereapi.com/v8/matrix?"+query,
+			strings.NewReader(body),
+		)
+		assert.NilError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		return client.Do(req)
+	}
+	// Identical requests are replayed in order, and the last one is repeated. The query parameters and the JSON
+	// body are matched regardless of their order and formatting, and the apiKey is not matched.
+	for _, expected := range []string{"first", "second", "second"} {
+		resp, err := do("async=false&apiKey=other", `{"regionDefinition":{"type":"world"},"origins":[{"lng":2,"lat":1}]}`)
+		assert.NilError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, resp.Body.Close())
+		assert.Equal(t, string(body), expected)
+	}
+	_, err = do("async=true&apiKey=other", `{"origins":[{"lat":1,"lng":2}],"regionDefinition":{"type":"world"}}`)
+	var unmatched *cassette.UnmatchedRequestError
+	assert.Assert(t, errors.As(err, &unmatched))
+	assert.Equal(t, unmatched.Method, http.MethodPost)
+	assert.Equal(t, unmatched.URL, "https://matrix.router.hereapi.com/v8/matrix?apiKey=REDACTED&async=true")
+}
+
+func TestClient_Record_Redacts(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "cassette.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Session", "session")
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+	client, err := cassette.NewHTTPClient(nil, cassette.Config{
+		Path:          path,
+		Mode:          cassette.ModeRecord,
+		RedactQuery:   []string{"token"},
+		RedactHeaders: []string{"X-Session"},
+	})
+	assert.NilError(t, err)
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, server.URL+"/v1/geocode?q=a&apiKey=key&token=token", nil,
+	)
+	assert.NilError(t, err)
+	req.Header.Set("Authorization", "Bearer bearer")
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	assert.NilError(t, resp.Body.Close())
+	// The response returned to the caller is not redacted.
+	assert.Equal(t, resp.Header.Get("X-Session"), "session")
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	for _, secret := range []string{"=key", "=token", "bearer", `"session"`} {
+		assert.Assert(t, !strings.Contains(string(data), secret), secret)
+	}
+}
+
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewMiddleware_RestoresBody(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(server.Close)
+	middleware, err := cassette.NewMiddleware(cassette.Config{
+		Path: filepath.Join(t.TempDir(), "cassette.json"),
+		Mode: cassette.ModeRecord,
+	})
+	assert.NilError(t, err)
+	// The next client reproduces the body through GetBody, as a retrying client does.
+	var reproduced []byte
+	client := middleware(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		if reproduced, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+		return http.DefaultClient.Do(req)
+	}))
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodPost, server.URL+"/v8/matrix", io.NopCloser(strings.NewReader(`{"a":1}`)),
+	)
+	assert.NilError(t, err)
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"a":1}`)
+	assert.Equal(t, string(reproduced), `{"a":1}`)
+}
+
+func TestNewHTTPClient_MissingCassette(t *testing.T) {
+	t.Parallel()
+	_, err := cassette.NewHTTPClient(nil, cassette.Config{Path: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Assert(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.einride.tech/here/geocodingsearchv7"
+)
+
+func runBatch(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var file, country, zipFile string
+	var reverse bool
+	var pollInterval time.Duration
+	flags.StringVar(&file, "file", "", "file with one query per line, or lat,lng with -reverse, or - for stdin")
+	flags.StringVar(&country, "country", "", "country of the queries, as ISO 3166-1 alpha-3 code")
+	flags.BoolVar(&reverse, "reverse", false, "reverse geocode positions instead of geocoding queries")
+	flags.DurationVar(&pollInterval, "poll-interval", 5*time.Second, "interval between polls of the job status")
+	flags.StringVar(&zipFile, "zip", "", "write the zipped result of the job to this file instead of stdout")
+	// The JSON input is a BatchGeocoderUploadRequest, or a BatchReverseGeocoderUploadRequest with -reverse.
+	var input json.RawMessage
+	if err := flags.parse(c, args, &input); err != nil {
+		return err
+	}
+	if flags.isSet("file") && flags.input != "" {
+		return usageErrorf("only one of -file and -input can be used")
+	}
+	var upload geocodingsearchv7.BatchGeocoderUploadRequest
+	var reverseUpload geocodingsearchv7.BatchReverseGeocoderUploadRequest
+	switch {
+	case input != nil && reverse:
+		if err := decodeStrict(input, &reverseUpload); err != nil {
+			return fmt.Errorf("decode input: %w", err)
+		}
+	case input != nil:
+		if err := decodeStrict(input, &upload); err != nil {
+			return fmt.Errorf("decode input: %w", err)
+		}
+	case file != "":
+		lines, err := c.readLines(file)
+		if err != nil {
+			return err
+		}
+		for i, line := range lines {
+			recID := strconv.Itoa(i + 1)
+			if !reverse {
+				upload.Queries = append(upload.Queries, &geocodingsearchv7.QueryString{
+					RecID:   recID,
+					Query:   line,
+					Country: country,
+				})
+				continue
+			}
+			p, err := parsePosition(line)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", i+1, err)
+			}
+			reverseUpload.GeoPositions = append(reverseUpload.GeoPositions, &geocodingsearchv7.GeoWaypointRequest{
+				RecID:        recID,
+				GeoPositions: &geocodingsearchv7.GeoWaypoint{Lat: p.lat, Long: p.lng},
+			})
+		}
+	default:
+		return usageErrorf("file or input is required")
+	}
+	client, err := c.geocodingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	var job *geocodingsearchv7.BatchGeocoderResponse
+	if reverse {
+		job, err = client.BatchGeocoding.BatchReverseGeocoderUpload(ctx, &reverseUpload)
+	} else {
+		job, err = client.BatchGeocoding.BatchGeocoderUpload(ctx, &upload)
+	}
+	if err != nil {
+		return err
+	}
+	requestID := job.Response.MetaInfo.RequestID
+	fmt.Fprintf(c.stderr, "job %s: %s\n", requestID, job.Response.Status)
+	if err := waitForBatchJob(ctx, c, client, requestID, pollInterval); err != nil {
+		return err
+	}
+	var result bytes.Buffer
+	if err := client.BatchGeocoding.BatchGeocoderDownload(
+		ctx, &geocodingsearchv7.BatchGeocoderDownloadRequest{RequestID: requestID}, &result,
+	); err != nil {
+		return err
+	}
+	if zipFile != "" {
+		return os.WriteFile(zipFile, result.Bytes(), 0o600)
+	}
+	rows, err := parseBatchResult(result.Bytes())
+	if err != nil {
+		return err
+	}
+	return write(c.stdout, flags.output, rows, batchResult(rows))
+}
+
+// waitForBatchJob polls the status of a batch geocoder job until it is completed.
+func waitForBatchJob(
+	ctx context.Context,
+	c *cli,
+	client *geocodingsearchv7.Client,
+	requestID string,
+	pollInterval time.Duration,
+) error {
+	for {
+		status, err := client.BatchGeocoding.BatchGeocoderStatus(
+			ctx, &geocodingsearchv7.BatchGeocoderStatusRequest{RequestID: requestID},
+		)
+		if err != nil {
+			return err
+		}
+		switch status.Response.Status {
+		case geocodingsearchv7.JobStatusCompleted:
+			fmt.Fprintf(
+				c.stderr,
+				"job %s: %s, %d succeeded, %d failed\n",
+				requestID,
+				status.Response.Status,
+				status.Response.SuccessCount,
+				status.Response.ErrorCount,
+			)
+			return nil
+		case geocodingsearchv7.JobStatusFailed, geocodingsearchv7.JobStatusCancelled, geocodingsearchv7.JobStatusDeleted:
+			return fmt.Errorf("job %s %s", requestID, status.Response.Status)
+		}
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// readLines returns the non-empty lines of the named file, or of stdin for -.
+func (c *cli) readLines(name string) ([]string, error) {
+	r := c.stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// parseBatchResult parses the rows of the zipped result of a batch geocoder job.
+func parseBatchResult(data []byte) ([]geocodingsearchv7.BatchGeocoderResponseRow, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("parse result: %w", err)
+	}
+	var rows []geocodingsearchv7.BatchGeocoderResponseRow
+	for _, f := range archive.File {
+		if !strings.HasSuffix(f.Name, "_out.txt") {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("parse result: %w", err)
+		}
+		content, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse result: %w", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		header := strings.Split(strings.TrimSpace(lines[0]), "|")
+		for _, line := range lines[1:] {
+			row, err := parseBatchRow(header, strings.Split(strings.TrimSpace(line), "|"))
+			if err != nil {
+				return nil, fmt.Errorf("parse result: %w", err)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func parseBatchRow(header, values []string) (geocodingsearchv7.BatchGeocoderResponseRow, error) {
+	var row geocodingsearchv7.BatchGeocoderResponseRow
+	for i, column := range header {
+		if i >= len(values) {
+			break
+		}
+		value := values[i]
+		var err error
+		switch column {
+		case "recId":
+			row.RecID = value
+		case "SeqNumber":
+			row.SeqNumber, err = strconv.Atoi(value)
+		case "seqLength":
+			row.SeqLength, err = strconv.Atoi(value)
+		case "displayLatitude":
+			row.DisplayLatitude, err = strconv.ParseFloat(value, 64)
+		case "displayLongitude":
+			row.DisplayLongitude, err = strconv.ParseFloat(value, 64)
+		case "locationLabel":
+			row.LocationLabel = value
+		case "houseNumber":
+			row.HouseNumber = value
+		case "street":
+			row.Street = value
+		case "district":
+			row.District = value
+		case "city":
+			row.City = value
+		case "postalCode":
+			row.PostalCode = value
+		case "county":
+			row.County = value
+		case "state":
+			row.State = value
+		case "country":
+			row.Country = value
+		}
+		if err != nil && value != "" {
+			return row, fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+	return row, nil
+}
+
+// batchResult is the result of the batch command.
+type batchResult []geocodingsearchv7.BatchGeocoderResponseRow
+
+func (r batchResult) table() ([]string, [][]string) {
+	header := []string{"REC ID", "LABEL", "LAT", "LNG"}
+	rows := make([][]string, 0, len(r))
+	for _, row := range r {
+		rows = append(rows, []string{
+			row.RecID,
+			row.LocationLabel,
+			formatFloat(row.DisplayLatitude),
+			formatFloat(row.DisplayLongitude),
+		})
+	}
+	return header, rows
+}
+
+func (r batchResult) features() []feature {
+	features := make([]feature, 0, len(r))
+	for _, row := range r {
+		features = append(features, pointFeature(row.DisplayLatitude, row.DisplayLongitude, map[string]interface{}{
+			"recId": row.RecID,
+			"label": row.LocationLabel,
+		}))
+	}
+	return features
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+
+	"go.einride.tech/here/geocodingsearchv7"
+)
+
+func runGeocode(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var q, at, in, lang, types string
+	var limit int
+	flags.StringVar(&q, "q", "", "free text query, e.g. \"Regeringsgatan 65, Stockholm\"")
+	flags.StringVar(&at, "at", "", "center of the search context as lat,lng")
+	flags.StringVar(&in, "in", "", "area to search within, e.g. countryCode:SWE or circle:59.33,18.06;r=5000")
+	flags.IntVar(&limit, "limit", 0, "maximum number of results")
+	flags.StringVar(&lang, "lang", "", "comma-separated preferred languages of the results")
+	flags.StringVar(&types, "types", "", "comma-separated result types, e.g. houseNumber,street")
+	var request geocodingsearchv7.GeocodingRequest
+	if err := flags.parse(c, args, &request); err != nil {
+		return err
+	}
+	if flags.isSet("q") {
+		request.Q = &q
+	}
+	if flags.isSet("at") {
+		p, err := parsePosition(at)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.GeoPosition = &geocodingsearchv7.GeoWaypoint{Lat: p.lat, Long: p.lng}
+	}
+	if flags.isSet("in") {
+		area, err := geocodingsearchv7.ParseSearchArea(in)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.Area = area
+	}
+	if flags.isSet("limit") {
+		request.Limit = limit
+	}
+	if flags.isSet("lang") {
+		request.Lang = splitList(lang)
+	}
+	if flags.isSet("types") {
+		request.Types = nil
+		for _, t := range splitList(types) {
+			request.Types = append(request.Types, geocodingsearchv7.GeocodingType(t))
+		}
+	}
+	if request.Q == nil && request.Address == nil {
+		return usageErrorf("query is required")
+	}
+	client, err := c.geocodingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	response, err := client.Geocoding.Geocoding(ctx, &request)
+	if err != nil {
+		return err
+	}
+	var places placesResult
+	for _, item := range response.Items {
+		places = append(places, place{
+			title:      item.Title,
+			id:         item.ID,
+			resultType: item.ResultType,
+			label:      item.Address.Label,
+			position:   item.Position,
+		})
+	}
+	return write(c.stdout, flags.output, response, places)
+}
+
+func runReverseGeocode(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var at, in string
+	flags.StringVar(&at, "at", "", "position to find the address of, as lat,lng")
+	flags.StringVar(
+		&in, "in", "", "area to search within, e.g. countryCode:SWE, or circle:59.33,18.06;r=5000 instead of -at",
+	)
+	var request geocodingsearchv7.ReverseGeocodingRequest
+	if err := flags.parse(c, args, &request); err != nil {
+		return err
+	}
+	if flags.isSet("at") {
+		p, err := parsePosition(at)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.GeoPosition = &geocodingsearchv7.GeoWaypoint{Lat: p.lat, Long: p.lng}
+	}
+	if flags.isSet("in") {
+		area, err := geocodingsearchv7.ParseSearchArea(in)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.Area = area
+	}
+	hasShape := request.Area != nil && (request.Area.Circle != nil || request.Area.BoundingBox != nil)
+	if request.GeoPosition == nil && !hasShape {
+		return usageErrorf("position, or an area with a circle or bounding box, is required")
+	}
+	client, err := c.geocodingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	response, err := client.ReverseGeocoding.ReverseGeocoding(ctx, &request)
+	if err != nil {
+		return err
+	}
+	var places placesResult
+	for _, item := range response.Items {
+		places = append(places, place{
+			title:      item.Title,
+			id:         item.ID,
+			resultType: item.ResultType,
+			label:      item.Address.Label,
+			position:   item.Position,
+		})
+	}
+	return write(c.stdout, flags.output, response, places)
+}
+
+// place is a geocoding result.
+type place struct {
+	title      string
+	id         string
+	resultType string
+	label      string
+	position   geocodingsearchv7.GeoWaypoint
+}
+
+// placesResult is the result of the geocode and revgeocode commands.
+type placesResult []place
+
+func (r placesResult) table() ([]string, [][]string) {
+	header := []string{"TITLE", "TYPE", "LAT", "LNG", "ID"}
+	rows := make([][]string, 0, len(r))
+	for _, p := range r {
+		rows = append(rows, []string{
+			p.title,
+			p.resultType,
+			formatFloat(p.position.Lat),
+			formatFloat(p.position.Long),
+			p.id,
+		})
+	}
+	return header, rows
+}
+
+func (r placesResult) features() []feature {
+	features := make([]feature, 0, len(r))
+	for _, p := range r {
+		features = append(features, pointFeature(p.position.Lat, p.position.Long, map[string]interface{}{
+			"title":      p.title,
+			"id":         p.id,
+			"resultType": p.resultType,
+			"label":      p.label,
+		}))
+	}
+	return features
+}
@@ -0,0 +1,323 @@
+// Command here calls the HERE routing, matrix and geocoding APIs from the command line.
+//
+// Usage:
+//
+//	here <command> [flags]
+//
+// The commands are:
+//
+//	route       calculate routes between an origin and a destination
+//	import      import a route from a trace of points
+//	matrix      calculate a matrix of routes between origins and destinations
+//	geocode     find the positions of addresses and places
+//	revgeocode  find the addresses of positions
+//	batch       geocode a file of addresses with the batch geocoder
+//
+// Each command accepts its request either as flags, or as the JSON encoded request struct of its package with
+// -input, e.g. a routingv8.RoutesRequest for route. Flags override fields of the JSON input. The response is
+// written to stdout as JSON, as a table or as GeoJSON, selected with -output.
+//
+// Requests are authenticated with the API key in the HERE_API_KEY environment variable. Without an API key,
+// HERE OAuth 2.0 credentials are used, as found by oauth.DefaultCredentials.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/oauth"
+	"go.einride.tech/here/routingv8"
+)
+
+// EnvAPIKey is the environment variable of the API key used to authenticate requests.
+const EnvAPIKey = "HERE_API_KEY"
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	cli := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	os.Exit(cli.run(ctx, os.Args[1:]))
+}
+
+// cli runs commands with the given standard streams and environment.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	// run parses the arguments of the command with flags, and calls the API.
+	run func(ctx context.Context, c *cli, flags *commonFlags, args []string) error
+}
+
+var commands = []command{
+	{name: "route", summary: "calculate routes between an origin and a destination", run: runRoute},
+	{name: "import", summary: "import a route from a trace of points", run: runImport},
+	{name: "matrix", summary: "calculate a matrix of routes between origins and destinations", run: runMatrix},
+	{name: "geocode", summary: "find the positions of addresses and places", run: runGeocode},
+	{name: "revgeocode", summary: "find the addresses of positions", run: runReverseGeocode},
+	{name: "batch", summary: "geocode a file of addresses with the batch geocoder", run: runBatch},
+}
+
+// run runs the command named by the first argument, and returns the exit code.
+func (c *cli) run(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		c.usage()
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		flags := newCommonFlags(cmd.name, c.stderr)
+		if err := cmd.run(ctx, c, flags, args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			fmt.Fprintf(c.stderr, "here %s: %v\n", cmd.name, err)
+			var usageErr usageError
+			if errors.As(err, &usageErr) {
+				return 2
+			}
+			return 1
+		}
+		return 0
+	}
+	fmt.Fprintf(c.stderr, "here: unknown command %q\n", args[0])
+	c.usage()
+	return 2
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "Usage: here <command> [flags]")
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stderr, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, `Run "here <command> -h" for the flags of a command.`)
+}
+
+// usageError is an error in the arguments of a command.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
+
+// commonFlags are the flags shared by all commands.
+type commonFlags struct {
+	*flag.FlagSet
+	output  string
+	input   string
+	baseURL string
+	timeout time.Duration
+}
+
+func newCommonFlags(name string, stderr io.Writer) *commonFlags {
+	f := &commonFlags{FlagSet: flag.NewFlagSet("here "+name, flag.ContinueOnError)}
+	f.SetOutput(stderr)
+	f.StringVar(&f.output, "output", outputTable, "output format: json, table or geojson")
+	f.StringVar(&f.input, "input", "", "file with the JSON encoded request, or - for stdin")
+	f.StringVar(&f.baseURL, "base-url", "", "base URL of the HERE APIs, e.g. of a proxy")
+	f.DurationVar(&f.timeout, "timeout", time.Minute, "timeout of the command")
+	return f
+}
+
+// parse parses the arguments, and decodes the JSON input into request, if any.
+func (f *commonFlags) parse(c *cli, args []string, request interface{}) error {
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{err: err}
+	}
+	if f.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(f.Args(), " "))
+	}
+	switch f.output {
+	case outputJSON, outputTable, outputGeoJSON:
+	default:
+		return usageErrorf("invalid output %q, must be json, table or geojson", f.output)
+	}
+	if f.input == "" {
+		return nil
+	}
+	var r io.Reader = c.stdin
+	if f.input != "-" {
+		file, err := os.Open(f.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		return fmt.Errorf("decode input: %w", err)
+	}
+	return nil
+}
+
+// isSet reports whether the flag with the given name was set on the command line.
+func (f *commonFlags) isSet(name string) bool {
+	var set bool
+	f.Visit(func(fl *flag.Flag) {
+		if fl.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseBaseURL returns the base URL of the flags, or nil if none was set.
+func (f *commonFlags) parseBaseURL() (*url.URL, error) {
+	if f.baseURL == "" {
+		return nil, nil
+	}
+	baseURL, err := url.Parse(f.baseURL)
+	if err != nil {
+		return nil, usageErrorf("invalid base URL: %w", err)
+	}
+	return baseURL, nil
+}
+
+// httpClient returns an HTTP client which authenticates requests with the API key or OAuth credentials of the
+// environment.
+func (c *cli) httpClient() (*http.Client, error) {
+	if apiKey := c.getenv(EnvAPIKey); apiKey != "" {
+		return routingv8.NewAPIKeyHTTPClient(apiKey, nil), nil
+	}
+	credentials, err := oauth.DefaultCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("no credentials, set %s or the HERE OAuth credentials: %w", EnvAPIKey, err)
+	}
+	source, err := oauth.NewTokenSource(oauth.TokenSourceConfig{Credentials: credentials})
+	if err != nil {
+		return nil, err
+	}
+	return oauth.NewHTTPClient(source, nil), nil
+}
+
+func (c *cli) routingClient(flags *commonFlags) (*routingv8.Client, error) {
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := flags.parseBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	var opts []routingv8.Option
+	if baseURL != nil {
+		opts = append(opts, routingv8.WithBaseURL(baseURL))
+	}
+	return routingv8.NewClient(httpClient, opts...), nil
+}
+
+func (c *cli) geocodingClient(flags *commonFlags) (*geocodingsearchv7.Client, error) {
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := flags.parseBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	var opts []geocodingsearchv7.Option
+	if baseURL != nil {
+		opts = append(opts, geocodingsearchv7.WithBaseURL(baseURL))
+	}
+	return geocodingsearchv7.NewClient(httpClient, opts...), nil
+}
+
+// position is a flag value of the form lat,lng.
+type position struct {
+	lat, lng float64
+}
+
+func parsePosition(s string) (position, error) {
+	latStr, lngStr, ok := strings.Cut(strings.TrimSpace(s), ",")
+	if !ok {
+		return position{}, fmt.Errorf("invalid position %q, must be lat,lng", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return position{}, fmt.Errorf("invalid latitude in %q", s)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return position{}, fmt.Errorf("invalid longitude in %q", s)
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return position{}, fmt.Errorf("position %q out of range", s)
+	}
+	return position{lat: lat, lng: lng}, nil
+}
+
+// parsePositions parses positions of the form lat,lng;lat,lng.
+func parsePositions(s string) ([]position, error) {
+	var positions []position
+	for _, part := range strings.Split(s, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		p, err := parsePosition(part)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// listFlag is a flag which can be repeated, and collects its values.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty values.
+func splitList(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/heretest"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+// runCLI runs the CLI against server, and returns its exit code, stdout and stderr.
+func runCLI(t *testing.T, server *heretest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	c := &cli{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string {
+			if key == EnvAPIKey {
+				return "key"
+			}
+			return ""
+		},
+	}
+	if len(args) > 0 {
+		args = append([]string{args[0], "-base-url", server.URL()}, args[1:]...)
+	}
+	code := c.run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI_Route(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key"})
+	t.Cleanup(server.Close)
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, "",
+			"route", "-origin", "57.70775,11.94977", "-destination", "59.33749,18.06367", "-via", "58.4,15.6",
+			"-mode", "truck", "-output", "json",
+		)
+		assert.Equal(t, code, 0, stderr)
+		var response routingv8.RoutesResponse
+		assert.NilError(t, json.Unmarshal([]byte(stdout), &response))
+		assert.Equal(t, len(response.Routes), 1)
+		assert.Equal(t, len(response.Routes[0].Sections), 2)
+		requests := server.Requests()
+		query := requests[len(requests)-1].Query
+		assert.Equal(t, query.Get("transportMode"), "truck")
+	})
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, "", "route", "-origin", "57.70775,11.94977", "-destination", "59.33749,18.06367",
+		)
+		assert.Equal(t, code, 0, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		assert.Equal(t, len(lines), 2)
+		assert.Assert(t, strings.HasPrefix(lines[0], "ROUTE"))
+		assert.Assert(t, strings.Contains(lines[1], "57.707750,11.949770"))
+	})
+	t.Run("geojson", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, `{"Origin":{"lat":57.70775,"lng":11.94977},"Destination":{"lat":59.33749,"lng":18.06367}}`,
+			"route", "-input", "-", "-output", "geojson",
+		)
+		assert.Equal(t, code, 0, stderr)
+		var collection featureCollection
+		assert.NilError(t, json.Unmarshal([]byte(stdout), &collection))
+		assert.Equal(t, collection.Type, "FeatureCollection")
+		assert.Equal(t, len(collection.Features), 1)
+		assert.Equal(t, collection.Features[0].Geometry.Type, "LineString")
+		coordinates := collection.Features[0].Geometry.Coordinates.([]interface{})
+		assert.DeepEqual(t, coordinates[0], []interface{}{11.94977, 57.70775})
+	})
+	t.Run("input with flags", func(t *testing.T) {
+		t.Parallel()
+		// Flags override the JSON input.
+		code, stdout, stderr := runCLI(
+			t, server, `{"Origin":{"lat":1,"lng":1},"Destination":{"lat":59.33749,"lng":18.06367},"TransportMode":"truck"}`,
+			"route", "-input", "-", "-origin", "57.70775,11.94977", "-output", "json",
+		)
+		assert.Equal(t, code, 0, stderr)
+		var response routingv8.RoutesResponse
+		assert.NilError(t, json.Unmarshal([]byte(stdout), &response))
+		assert.Equal(t, response.Routes[0].Sections[0].Departure.Place.Location.Lat, 57.70775)
+	})
+}
+
+func TestCLI_Import(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key"})
+	t.Cleanup(server.Close)
+	code, stdout, stderr := runCLI(
+		t, server, "", "import", "-trace", "57.70775,11.94977;58.4,15.6;59.33749,18.06367", "-output", "json",
+	)
+	assert.Equal(t, code, 0, stderr)
+	var response routingv8.RoutesResponse
+	assert.NilError(t, json.Unmarshal([]byte(stdout), &response))
+	assert.Equal(t, len(response.Routes), 1)
+}
+
+func TestCLI_Matrix(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key", PendingPolls: 1})
+	t.Cleanup(server.Close)
+	for _, async := range []string{"-async=false", "-async=true"} {
+		async := async
+		t.Run(async, func(t *testing.T) {
+			t.Parallel()
+			code, stdout, stderr := runCLI(
+				t, server, "",
+				"matrix", "-origins", "57.70775,11.94977;59.33749,18.06367", "-mode", "truck", "-output", "json", async,
+			)
+			assert.Equal(t, code, 0, stderr)
+			var response routingv8.CalculateMatrixResponse
+			assert.NilError(t, json.Unmarshal([]byte(stdout), &response))
+			assert.Equal(t, response.Matrix.NumOrigins, 2)
+			assert.Equal(t, response.Matrix.NumDestinations, 2)
+			assert.Equal(t, len(response.Matrix.TravelTimes), 4)
+			assert.Equal(t, len(response.Matrix.Distances), 4)
+		})
+	}
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, "",
+			"matrix", "-origins", "57.70775,11.94977", "-destinations", "59.33749,18.06367;58.4,15.6",
+		)
+		assert.Equal(t, code, 0, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		assert.Equal(t, len(lines), 3)
+		assert.Assert(t, strings.HasPrefix(lines[0], "ORIGIN"))
+	})
+}
+
+func TestCLI_Geocode(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{
+		APIKey: "key",
+		Places: []heretest.Place{
+			{
+				Title:    "Einride Stockholm",
+				Position: geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+				Address:  geocodingsearchv7.Address{Label: "Regeringsgatan 65, Stockholm", CountryCode: "SWE"},
+			},
+		},
+	})
+	t.Cleanup(server.Close)
+	t.Run("geocode", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, "", "geocode", "-q", "Regeringsgatan 65, Stockholm", "-in", "countryCode:SWE", "-limit", "3",
+		)
+		assert.Equal(t, code, 0, stderr)
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		assert.Equal(t, len(lines), 2)
+		assert.Assert(t, strings.HasPrefix(lines[1], "Einride Stockholm"))
+		assert.Assert(t, strings.Contains(lines[1], "59.337490"))
+	})
+	t.Run("revgeocode", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(t, server, "", "revgeocode", "-at", "59.3375,18.0637", "-output", "geojson")
+		assert.Equal(t, code, 0, stderr)
+		var collection featureCollection
+		assert.NilError(t, json.Unmarshal([]byte(stdout), &collection))
+		assert.Equal(t, len(collection.Features), 1)
+		assert.Equal(t, collection.Features[0].Properties["title"], "Einride Stockholm")
+	})
+	t.Run("revgeocode in circle", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(t, server, "", "revgeocode", "-in", "circle:59.3375,18.0637;r=500")
+		assert.Equal(t, code, 0, stderr)
+		assert.Assert(t, strings.Contains(stdout, "Einride Stockholm"), stdout)
+	})
+}
+
+func TestCLI_Batch(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key", PendingPolls: 1})
+	t.Cleanup(server.Close)
+	t.Run("geocode", func(t *testing.T) {
+		t.Parallel()
+		code, stdout, stderr := runCLI(
+			t, server, "Regeringsgatan 65, Stockholm\n\nLindholmspiren 3, Göteborg\n",
+			"batch", "-file", "-", "-country", "SWE", "-poll-interval", "1ms", "-output", "json",
+		)
+		assert.Equal(t, code, 0, stderr)
+		assert.Assert(t, strings.Contains(stderr, "completed, 2 succeeded"), stderr)
+		var rows []geocodingsearchv7.BatchGeocoderResponseRow
+		assert.NilError(t, json.Unmarshal([]byte(stdout), &rows))
+		assert.Equal(t, len(rows), 2)
+		assert.Equal(t, rows[0].RecID, "1")
+		assert.Equal(t, rows[0].LocationLabel, "Regeringsgatan 65, Stockholm")
+		assert.Equal(t, rows[1].RecID, "2")
+	})
+	t.Run("reverse", func(t *testing.T) {
+		t.Parallel()
+		zipFile := filepath.Join(t.TempDir(), "result.zip")
+		code, _, stderr := runCLI(
+			t, server, "59.33749,18.06367\n",
+			"batch", "-file", "-", "-reverse", "-poll-interval", "1ms", "-zip", zipFile,
+		)
+		assert.Equal(t, code, 0, stderr)
+		data, err := os.ReadFile(zipFile)
+		assert.NilError(t, err)
+		rows, err := parseBatchResult(data)
+		assert.NilError(t, err)
+		assert.Equal(t, len(rows), 1)
+		assert.Equal(t, rows[0].DisplayLatitude, 59.33749)
+	})
+}
+
+func TestCLI_Errors(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key"})
+	t.Cleanup(server.Close)
+	for _, tt := range []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{name: "no command", code: 2, stderr: "Usage: here <command>"},
+		{name: "unknown command", args: []string{"fly"}, code: 2, stderr: `unknown command "fly"`},
+		{name: "missing origin", args: []string{"route"}, code: 2, stderr: "origin and destination are required"},
+		{
+			name:   "invalid position",
+			args:   []string{"route", "-origin", "57.7", "-destination", "59.3,18.1"},
+			code:   2,
+			stderr: `invalid position "57.7"`,
+		},
+		{
+			name:   "invalid mode",
+			args:   []string{"route", "-origin", "57.7,11.9", "-destination", "59.3,18.1", "-mode", "boat"},
+			code:   2,
+			stderr: `invalid transport mode "boat"`,
+		},
+		{
+			name:   "invalid search area",
+			args:   []string{"geocode", "-q", "Stockholm", "-in", "countryCode:se"},
+			code:   2,
+			stderr: `invalid search area: invalid country code "se"`,
+		},
+		{
+			name:   "invalid output",
+			args:   []string{"geocode", "-q", "Stockholm", "-output", "xml"},
+			code:   2,
+			stderr: `invalid output "xml"`,
+		},
+		{
+			name:   "api error",
+			args:   []string{"route", "-origin", "57.7,11.9", "-destination", "59.3,18.1", "-return", "instructions"},
+			code:   1,
+			stderr: "instructions option in the return parameter also requires the actions option",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, stdout, stderr := runCLI(t, server, "", tt.args...)
+			assert.Equal(t, code, tt.code)
+			assert.Equal(t, stdout, "")
+			assert.Assert(t, strings.Contains(stderr, tt.stderr), stderr)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.einride.tech/here/routingv8"
+)
+
+func runMatrix(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var origins, destinations, transportMode, profile, attributes, region, departureTime string
+	var async bool
+	flags.StringVar(&origins, "origins", "", "origins as lat,lng;lat,lng;...")
+	flags.StringVar(&destinations, "destinations", "", "destinations as lat,lng;lat,lng;..., defaults to the origins")
+	flags.StringVar(&transportMode, "mode", "", "transport mode: car, truck, pedestrian, bicycle, taxi or scooter")
+	flags.StringVar(&profile, "profile", "", "profile: carFast, carShort, truckFast, pedestrian or bicycle")
+	flags.StringVar(&attributes, "attributes", "travelTimes,distances", "comma-separated matrix attributes")
+	flags.StringVar(&region, "region", "world", "region type: world or autoCircle")
+	flags.StringVar(&departureTime, "departure-time", "", "departure time in RFC 3339 format, or any")
+	flags.BoolVar(&async, "async", false, "calculate the matrix asynchronously, as required for large matrices")
+	request := routingv8.CalculateMatrixRequest{Body: &routingv8.CalculateMatrixBody{}}
+	if err := flags.parse(c, args, &request); err != nil {
+		return err
+	}
+	if request.Body == nil {
+		request.Body = &routingv8.CalculateMatrixBody{}
+	}
+	body := request.Body
+	if flags.isSet("origins") {
+		waypoints, err := parseGeoWaypoints(origins)
+		if err != nil {
+			return usageError{err: err}
+		}
+		body.Origins = waypoints
+	}
+	if flags.isSet("destinations") {
+		waypoints, err := parseGeoWaypoints(destinations)
+		if err != nil {
+			return usageError{err: err}
+		}
+		body.Destinations = waypoints
+	}
+	if len(body.Origins) == 0 {
+		return usageErrorf("origins are required")
+	}
+	if flags.isSet("mode") {
+		if err := body.TransportMode.UnmarshalString(transportMode); err != nil {
+			return usageErrorf("%w %q", err, transportMode)
+		}
+	}
+	if flags.isSet("profile") {
+		if err := body.Profile.UnmarshalString(profile); err != nil {
+			return usageErrorf("%w %q", err, profile)
+		}
+	}
+	if flags.isSet("attributes") || body.MatrixAttributes == nil {
+		matrixAttributes := routingv8.MatrixAttributes{}
+		for _, value := range splitList(attributes) {
+			var attribute routingv8.MatrixAttribute
+			if err := attribute.UnmarshalString(value); err != nil {
+				return usageErrorf("%w %q", err, value)
+			}
+			matrixAttributes = append(matrixAttributes, attribute)
+		}
+		body.MatrixAttributes = &matrixAttributes
+	}
+	if flags.isSet("region") || body.RegionDefinition.Type == routingv8.RegionTypeUnspecified {
+		if err := body.RegionDefinition.Type.UnmarshalString(region); err != nil {
+			return usageErrorf("%w %q", err, region)
+		}
+	}
+	if flags.isSet("departure-time") {
+		body.DepartureTime = departureTime
+	}
+	if flags.isSet("async") {
+		request.Async = routingv8.Async(async)
+	}
+	client, err := c.routingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	response, err := client.Matrix.CalculateTiledMatrix(ctx, &routingv8.CalculateTiledMatrixRequest{Request: &request})
+	if err != nil {
+		return err
+	}
+	return write(c.stdout, flags.output, response, matrixResult{body: body, response: response})
+}
+
+func parseGeoWaypoints(s string) ([]*routingv8.GeoWaypoint, error) {
+	positions, err := parsePositions(s)
+	if err != nil {
+		return nil, err
+	}
+	waypoints := make([]*routingv8.GeoWaypoint, 0, len(positions))
+	for _, p := range positions {
+		waypoints = append(waypoints, &routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng})
+	}
+	return waypoints, nil
+}
+
+// matrixResult is the result of the matrix command.
+type matrixResult struct {
+	body     *routingv8.CalculateMatrixBody
+	response *routingv8.CalculateMatrixResponse
+}
+
+func (r matrixResult) table() ([]string, [][]string) {
+	header := []string{"ORIGIN", "DESTINATION", "TRAVEL TIME", "DISTANCE", "ERROR"}
+	var rows [][]string
+	r.response.Matrix.Range(func(cell routingv8.MatrixCell) bool {
+		row := []string{strconv.Itoa(cell.OriginIndex), strconv.Itoa(cell.DestinationIndex), "", "", ""}
+		if cell.Err != nil {
+			row[4] = cell.Err.Error()
+		} else {
+			row[2] = cell.TravelTime.String()
+			row[3] = fmt.Sprintf("%dm", cell.DistanceMeters)
+		}
+		rows = append(rows, row)
+		return true
+	})
+	return header, rows
+}
+
+func (r matrixResult) features() []feature {
+	destinations := r.body.Destinations
+	var features []feature
+	r.response.Matrix.Range(func(cell routingv8.MatrixCell) bool {
+		if cell.OriginIndex >= len(r.body.Origins) || cell.DestinationIndex >= len(destinations) {
+			return true
+		}
+		origin, destination := r.body.Origins[cell.OriginIndex], destinations[cell.DestinationIndex]
+		properties := map[string]interface{}{
+			"origin":      cell.OriginIndex,
+			"destination": cell.DestinationIndex,
+		}
+		if cell.Err != nil {
+			properties["error"] = cell.Err.Error()
+		} else {
+			properties["travelTime"] = int64(cell.TravelTime.Seconds())
+			properties["distance"] = cell.DistanceMeters
+		}
+		features = append(features, lineStringFeature(
+			[][2]float64{{origin.Long, origin.Lat}, {destination.Long, destination.Lat}},
+			properties,
+		))
+		return true
+	})
+	return features
+}
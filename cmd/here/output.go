@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	outputJSON    = "json"
+	outputTable   = "table"
+	outputGeoJSON = "geojson"
+)
+
+// result is the response of a command, writable in every output format.
+type result interface {
+	// table returns the header and the rows of the table output.
+	table() (header []string, rows [][]string)
+	// features returns the GeoJSON features of the geojson output.
+	features() []feature
+}
+
+// write writes the response of a command to w, in the given output format. The JSON output is the response itself.
+func write(w io.Writer, output string, response interface{}, r result) error {
+	switch output {
+	case outputJSON:
+		return writeJSON(w, response)
+	case outputGeoJSON:
+		features := r.features()
+		if features == nil {
+			features = []feature{}
+		}
+		return writeJSON(w, featureCollection{Type: "FeatureCollection", Features: features})
+	default:
+		header, rows := r.table()
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
+
+// featureCollection is a GeoJSON feature collection.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature is a GeoJSON feature.
+type feature struct {
+	Type       string                 `json:"type"`
+	Geometry   geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geometry is a GeoJSON geometry, with coordinates in longitude, latitude order.
+type geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func pointFeature(lat, lng float64, properties map[string]interface{}) feature {
+	return feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "Point", Coordinates: [2]float64{lng, lat}},
+		Properties: properties,
+	}
+}
+
+func lineStringFeature(coordinates [][2]float64, properties map[string]interface{}) feature {
+	return feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "LineString", Coordinates: coordinates},
+		Properties: properties,
+	}
+}
+
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%.6f", f)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.einride.tech/here/routingv8"
+)
+
+func runRoute(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var origin, destination string
+	var via listFlag
+	flags.StringVar(&origin, "origin", "", "origin as lat,lng")
+	flags.StringVar(&destination, "destination", "", "destination as lat,lng")
+	flags.Var(&via, "via", "via waypoint as lat,lng, can be repeated")
+	var routeFlags routeFlags
+	routeFlags.register(flags)
+	var request routingv8.RoutesRequest
+	if err := flags.parse(c, args, &request); err != nil {
+		return err
+	}
+	if flags.isSet("origin") {
+		p, err := parsePosition(origin)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.Origin = routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng}
+	}
+	if flags.isSet("destination") {
+		p, err := parsePosition(destination)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.Destination = routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng}
+	}
+	if flags.isSet("via") {
+		request.Via = nil
+		for _, v := range via {
+			p, err := parsePosition(v)
+			if err != nil {
+				return usageError{err: err}
+			}
+			request.Via = append(request.Via, routingv8.Via{Location: routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng}})
+		}
+	}
+	if request.Origin == (routingv8.GeoWaypoint{}) || request.Destination == (routingv8.GeoWaypoint{}) {
+		return usageErrorf("origin and destination are required")
+	}
+	if err := routeFlags.apply(flags, routeFields{
+		transportMode: &request.TransportMode,
+		routingMode:   &request.RoutingMode,
+		returns:       &request.Return,
+		departureTime: &request.DepartureTime,
+		arrivalTime:   &request.ArrivalTime,
+		alternatives:  &request.Alternatives,
+		lang:          &request.Lang,
+	}); err != nil {
+		return err
+	}
+	client, err := c.routingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	response, err := client.Routing.Routes(ctx, &request)
+	if err != nil {
+		return err
+	}
+	return write(c.stdout, flags.output, response, routesResult{response: response})
+}
+
+func runImport(ctx context.Context, c *cli, flags *commonFlags, args []string) error {
+	var trace string
+	flags.StringVar(&trace, "trace", "", "trace points as lat,lng;lat,lng;...")
+	var routeFlags routeFlags
+	routeFlags.register(flags)
+	var request routingv8.RouteImportRequest
+	if err := flags.parse(c, args, &request); err != nil {
+		return err
+	}
+	if flags.isSet("trace") {
+		positions, err := parsePositions(trace)
+		if err != nil {
+			return usageError{err: err}
+		}
+		request.Trace = nil
+		for _, p := range positions {
+			request.Trace = append(request.Trace, routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng})
+		}
+	}
+	if len(request.Trace) < 2 {
+		return usageErrorf("trace of at least 2 points is required")
+	}
+	if err := routeFlags.apply(flags, routeFields{
+		transportMode: &request.TransportMode,
+		routingMode:   &request.RoutingMode,
+		returns:       &request.Return,
+		departureTime: &request.DepartureTime,
+		arrivalTime:   &request.ArrivalTime,
+		alternatives:  &request.Alternatives,
+		lang:          &request.Lang,
+	}); err != nil {
+		return err
+	}
+	client, err := c.routingClient(flags)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, flags.timeout)
+	defer cancel()
+	response, err := client.Routing.RouteImport(ctx, &request)
+	if err != nil {
+		return err
+	}
+	return write(c.stdout, flags.output, response, routesResult{response: response})
+}
+
+// routeFlags are the flags shared by the route and import commands.
+type routeFlags struct {
+	transportMode string
+	routingMode   string
+	returns       string
+	departureTime string
+	arrivalTime   string
+	alternatives  int
+	lang          string
+}
+
+// routeFields points to the fields of a request set by routeFlags.
+type routeFields struct {
+	transportMode *routingv8.TransportMode
+	routingMode   *routingv8.RoutingMode
+	returns       *[]routingv8.ReturnAttribute
+	departureTime *string
+	arrivalTime   *string
+	alternatives  *int
+	lang          *[]string
+}
+
+func (r *routeFlags) register(flags *commonFlags) {
+	flags.StringVar(&r.transportMode, "mode", "car", "transport mode: car, truck, pedestrian, bicycle, taxi or scooter")
+	flags.StringVar(&r.routingMode, "routing-mode", "", "routing mode: fast or short")
+	flags.StringVar(&r.returns, "return", "", "comma-separated attributes to return, e.g. summary,polyline")
+	flags.StringVar(&r.departureTime, "departure-time", "", "departure time in RFC 3339 format, or any")
+	flags.StringVar(&r.arrivalTime, "arrival-time", "", "arrival time in RFC 3339 format")
+	flags.IntVar(&r.alternatives, "alternatives", 0, "number of alternative routes")
+	flags.StringVar(&r.lang, "lang", "", "comma-separated preferred languages of instructions")
+}
+
+// apply sets the fields of a request from the flags which were set. The transport mode defaults to the flag, and
+// the return attributes needed by the output are added.
+func (r *routeFlags) apply(flags *commonFlags, fields routeFields) error {
+	if flags.isSet("mode") || *fields.transportMode == routingv8.TransportModeUnspecified {
+		if err := fields.transportMode.UnmarshalString(r.transportMode); err != nil {
+			return usageErrorf("%w %q", err, r.transportMode)
+		}
+	}
+	if flags.isSet("routing-mode") {
+		if err := fields.routingMode.UnmarshalString(r.routingMode); err != nil {
+			return usageErrorf("%w %q", err, r.routingMode)
+		}
+	}
+	if flags.isSet("return") {
+		*fields.returns = nil
+		for _, attribute := range splitList(r.returns) {
+			*fields.returns = append(*fields.returns, routingv8.ReturnAttribute(attribute))
+		}
+	}
+	if flags.isSet("departure-time") {
+		*fields.departureTime = r.departureTime
+	}
+	if flags.isSet("arrival-time") {
+		*fields.arrivalTime = r.arrivalTime
+	}
+	if flags.isSet("alternatives") {
+		*fields.alternatives = r.alternatives
+	}
+	if flags.isSet("lang") {
+		*fields.lang = splitList(r.lang)
+	}
+	switch flags.output {
+	case outputTable:
+		addReturn(fields.returns, routingv8.SummaryReturnAttribute)
+	case outputGeoJSON:
+		addReturn(fields.returns, routingv8.SummaryReturnAttribute)
+		addReturn(fields.returns, routingv8.PolylineReturnAttribute)
+	}
+	return nil
+}
+
+func addReturn(returns *[]routingv8.ReturnAttribute, attribute routingv8.ReturnAttribute) {
+	for _, r := range *returns {
+		if r == attribute {
+			return
+		}
+	}
+	*returns = append(*returns, attribute)
+}
+
+// routesResult is the result of the route and import commands.
+type routesResult struct {
+	response *routingv8.RoutesResponse
+}
+
+func (r routesResult) table() ([]string, [][]string) {
+	header := []string{"ROUTE", "SECTION", "DEPARTURE", "ARRIVAL", "DURATION", "LENGTH"}
+	var rows [][]string
+	for i, route := range r.response.Routes {
+		for j, section := range route.Sections {
+			rows = append(rows, []string{
+				strconv.Itoa(i),
+				strconv.Itoa(j),
+				formatPlace(section.Departure),
+				formatPlace(section.Arrival),
+				fmt.Sprintf("%ds", section.Summary.Duration),
+				fmt.Sprintf("%dm", section.Summary.Length),
+			})
+		}
+	}
+	return header, rows
+}
+
+func formatPlace(d routingv8.VehicleDeparture) string {
+	s := formatFloat(d.Place.Location.Lat) + "," + formatFloat(d.Place.Location.Long)
+	if d.Time != "" {
+		s += " " + d.Time
+	}
+	return s
+}
+
+func (r routesResult) features() []feature {
+	var features []feature
+	for i, route := range r.response.Routes {
+		for j, section := range route.Sections {
+			points, err := section.Polyline.Decode()
+			if err != nil || len(points) == 0 {
+				continue
+			}
+			coordinates := make([][2]float64, 0, len(points))
+			for _, p := range points {
+				coordinates = append(coordinates, [2]float64{p.Long, p.Lat})
+			}
+			features = append(features, lineStringFeature(coordinates, map[string]interface{}{
+				"route":    i,
+				"routeId":  route.ID,
+				"section":  j,
+				"duration": section.Summary.Duration,
+				"length":   section.Summary.Length,
+			}))
+		}
+	}
+	return features
+}
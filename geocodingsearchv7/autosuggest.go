@@ -0,0 +1,94 @@
+package geocodingsearchv7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Autosuggest suggests places, addresses and queries completing a partial free text query, such as the input of a
+// type-ahead address field. Location results can be resolved into the full item with their ID, and query
+// suggestions be followed with their Href.
+// See https://developer.here.com/documentation/geocoding-search-api/api-reference-swagger.html
+// for more details.
+func (s *AutosuggestService) Autosuggest(
+	ctx context.Context,
+	req *AutosuggestRequest,
+) (_ *AutosuggestResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "Autosuggest")
+	defer func() { op.End(err) }()
+	u, err := s.URL.Parse("autosuggest")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Q == "" {
+		return nil, fmt.Errorf("InvalidArgument, Q must be provided")
+	}
+	if req.GeoPosition == nil && !req.Area.hasShape() {
+		return nil, fmt.Errorf("InvalidArgument, %w", errMissingPosition)
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("InvalidArgument, Limit must not be negative")
+	}
+
+	values := make(url.Values)
+	values.Add("q", req.Q)
+	if err := addSearchParameters(values, req.GeoPosition, req.Area, req.Limit, req.Lang); err != nil {
+		return nil, err
+	}
+
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %w", err)
+	}
+	var resp AutosuggestResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// errMissingPosition is returned by searches with neither a position nor a search area with a shape to search
+// around.
+var errMissingPosition = errors.New("either GeoPosition or Area with a circle or bounding box must be provided")
+
+// addDeprecatedIn adds the deprecated in parameter of a request, which must not be combined with its Area.
+func addDeprecatedIn(values url.Values, in *string, area *SearchArea) error {
+	if in == nil {
+		return nil
+	}
+	if area != nil {
+		return fmt.Errorf("InvalidArgument, In and Area are mutually exclusive")
+	}
+	values.Add("in", *in)
+	return nil
+}
+
+// addSearchParameters adds the search context, limit and languages shared by the search endpoints to values. It
+// returns an error if the search area is invalid, or has a shape as well as a position.
+func addSearchParameters(values url.Values, at *GeoWaypoint, in *SearchArea, limit int, lang []string) error {
+	if in != nil {
+		if err := in.Validate(); err != nil {
+			return fmt.Errorf("InvalidArgument, %w", err)
+		}
+		if at != nil && (in.Circle != nil || in.BoundingBox != nil) {
+			return fmt.Errorf("InvalidArgument, GeoPosition must not be combined with a circle or bounding box")
+		}
+		values.Add("in", in.String())
+	}
+	if at != nil {
+		values.Add("at", fmt.Sprintf("%v,%v", at.Lat, at.Long))
+	}
+	if limit > 0 {
+		values.Add("limit", strconv.Itoa(limit))
+	}
+	if len(lang) > 0 {
+		values.Add("lang", strings.Join(lang, ","))
+	}
+	return nil
+}
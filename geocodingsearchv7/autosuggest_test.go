@@ -0,0 +1,154 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+const autosuggestResponse = `{
+  "items": [
+    {
+      "title": "Regeringsgatan 65, 111 56 Stockholm, Sverige",
+      "id": "here:af:streetsection:Vn2Mku7Kb5X7Xp-vK2GQeB:CgcIBCDn3ekZEAEaAjY1",
+      "resultType": "houseNumber",
+      "houseNumberType": "PA",
+      "address": {
+        "label": "Regeringsgatan 65, 111 56 Stockholm, Sverige",
+        "countryCode": "SWE",
+        "city": "Stockholm",
+        "street": "Regeringsgatan",
+        "houseNumber": "65"
+      },
+      "position": {"lat": 59.33749, "lng": 18.06367},
+      "access": [{"lat": 59.33743, "lng": 18.06383}],
+      "distance": 312,
+      "mapView": {"west": 18.06191, "south": 59.33659, "east": 18.06543, "north": 59.33839},
+      "highlighting": {
+        "title": [{"start": 0, "end": 10}],
+        "address": {"label": [{"start": 0, "end": 10}], "street": [{"start": 0, "end": 10}]}
+      }
+    },
+    {
+      "title": "Laddstation",
+      "id": "",
+      "resultType": "categoryQuery",
+      "href": "https://autosuggest.search.hereapi.com/v1/discover?at=59.33,18.06&q=Laddstation&_ontology=700-7600",
+      "categories": [{"id": "700-7600-0322", "name": "Laddstation"}],
+      "highlighting": {"title": [{"start": 0, "end": 4}]}
+    }
+  ],
+  "queryTerms": [{"term": "Regeringsgatan", "replaces": "Regerings", "start": 0, "end": 9}]
+}`
+
+func TestAutosuggestService_Autosuggest(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: autosuggestResponse}
+	client := geocodingsearchv7.NewClient(&mock)
+	got, err := client.Autosuggest.Autosuggest(context.Background(), &geocodingsearchv7.AutosuggestRequest{
+		Q:           "Regerings",
+		GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.33, Long: 18.06},
+		Area:        geocodingsearchv7.InCountries("SWE"),
+		Limit:       5,
+		Lang:        []string{"sv-SE", "en-US"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	request := mock.requests[0]
+	assert.Equal(t, request.URL.Host, "autosuggest.search.hereapi.com")
+	assert.Equal(t, request.URL.Path, "/v1/autosuggest")
+	query := request.URL.Query()
+	assert.Equal(t, query.Get("q"), "Regerings")
+	assert.Equal(t, query.Get("at"), "59.33,18.06")
+	assert.Equal(t, query.Get("in"), "countryCode:SWE")
+	assert.Equal(t, query.Get("limit"), "5")
+	assert.Equal(t, query.Get("lang"), "sv-SE,en-US")
+
+	assert.Equal(t, len(got.Items), 2)
+	address := got.Items[0]
+	assert.Equal(t, address.ResultType, geocodingsearchv7.ResultTypeHouseNumber)
+	assert.Assert(t, !address.IsQuery())
+	assert.Equal(t, address.Address.Street, "Regeringsgatan")
+	assert.DeepEqual(t, address.Position, &geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367})
+	assert.Equal(t, address.Distance, 312)
+	assert.Equal(t, address.Highlights.Title[0].Substring(address.Title), "Regeringsg")
+	assert.DeepEqual(t, address.Highlights.Address.Street, []geocodingsearchv7.HighlightRange{{Start: 0, End: 10}})
+	suggestion := got.Items[1]
+	assert.Assert(t, suggestion.IsQuery())
+	assert.Assert(t, suggestion.Position == nil)
+	assert.Equal(t, suggestion.Categories[0].ID, geocodingsearchv7.CategoryEVCharging)
+	assert.Equal(
+		t,
+		suggestion.Href,
+		"https://autosuggest.search.hereapi.com/v1/discover?at=59.33,18.06&q=Laddstation&_ontology=700-7600",
+	)
+	assert.DeepEqual(t, got.QueryTerms, []geocodingsearchv7.QueryTerm{
+		{Term: "Regeringsgatan", Replaces: "Regerings", Start: 0, End: 9},
+	})
+}
+
+func TestAutosuggestService_Autosuggest_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	at := &geocodingsearchv7.GeoWaypoint{Lat: 59.33, Long: 18.06}
+	for _, tt := range []struct {
+		name    string
+		request geocodingsearchv7.AutosuggestRequest
+		errStr  string
+	}{
+		{
+			name:    "missing query",
+			request: geocodingsearchv7.AutosuggestRequest{GeoPosition: at},
+			errStr:  "InvalidArgument, Q must be provided",
+		},
+		{
+			name:    "missing search context",
+			request: geocodingsearchv7.AutosuggestRequest{Q: "Regerings"},
+			errStr:  "InvalidArgument, either GeoPosition or Area with a circle or bounding box must be provided",
+		},
+		{
+			name:    "only countries",
+			request: geocodingsearchv7.AutosuggestRequest{Q: "Regerings", Area: geocodingsearchv7.InCountries("SWE")},
+			errStr:  "InvalidArgument, either GeoPosition or Area with a circle or bounding box must be provided",
+		},
+		{
+			name:    "negative limit",
+			request: geocodingsearchv7.AutosuggestRequest{Q: "Regerings", GeoPosition: at, Limit: -1},
+			errStr:  "InvalidArgument, Limit must not be negative",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := RawResponseMock{statusCode: http.StatusOK, body: autosuggestResponse}
+			client := geocodingsearchv7.NewClient(&mock)
+			_, err := client.Autosuggest.Autosuggest(context.Background(), &tt.request)
+			assert.Error(t, err, tt.errStr)
+			assert.Equal(t, len(mock.requests), 0)
+		})
+	}
+}
+
+func TestHighlightRange_Substring(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name       string
+		start, end int
+		s          string
+		expected   string
+	}{
+		{name: "prefix", start: 0, end: 4, s: "Göteborg", expected: "Göte"},
+		{name: "suffix", start: 4, end: 8, s: "Göteborg", expected: "borg"},
+		{name: "out of bounds", start: 4, end: 9, s: "Göteborg"},
+		{name: "inverted", start: 4, end: 2, s: "Göteborg"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			highlightRange := geocodingsearchv7.HighlightRange{Start: tt.start, End: tt.end}
+			assert.Equal(t, highlightRange.Substring(tt.s), tt.expected)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"go.einride.tech/here/telemetry"
 )
 
 // BatchGeocoderUpload allows batch forward geocoding of addresses.
@@ -23,6 +25,12 @@ func (s *BatchGeocodingService) BatchGeocoderUpload(
 	if req.Addresses == nil && req.Queries == nil {
 		return nil, fmt.Errorf("InvalidArgument, one of Addresses or Queries must be supplied")
 	}
+	ctx, op := s.Client.startOperation(
+		ctx,
+		"BatchGeocoderUpload",
+		telemetry.Int(telemetry.AttributeRecords, len(req.Addresses)+len(req.Queries)),
+	)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("jobs")
 	if err != nil {
 		return nil, err
@@ -45,11 +53,11 @@ func (s *BatchGeocodingService) BatchGeocoderUpload(
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, values.Encode(), body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create post request: %v", err)
+		return nil, fmt.Errorf("unable to create post request: %w", err)
 	}
 	var resp BatchGeocoderResponse
 	if err := s.Client.DoXML(r, &resp); err != nil {
-		return nil, fmt.Errorf("DoXML failed: %v", err)
+		return nil, fmt.Errorf("DoXML failed: %w", err)
 	}
 	return &resp, nil
 }
@@ -64,6 +72,12 @@ func (s *BatchGeocodingService) BatchReverseGeocoderUpload(
 	if req.GeoPositions == nil {
 		return nil, fmt.Errorf("InvalidArgument, geoPositions must be in the request")
 	}
+	ctx, op := s.Client.startOperation(
+		ctx,
+		"BatchReverseGeocoderUpload",
+		telemetry.Int(telemetry.AttributeRecords, len(req.GeoPositions)),
+	)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("jobs")
 	if err != nil {
 		return nil, err
@@ -83,11 +97,11 @@ func (s *BatchGeocodingService) BatchReverseGeocoderUpload(
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, values.Encode(), body)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create post request: %v", err)
+		return nil, fmt.Errorf("unable to create post request: %w", err)
 	}
 	var resp BatchGeocoderResponse
 	if err := s.Client.DoXML(r, &resp); err != nil {
-		return nil, fmt.Errorf("DoXML failed: %v", err)
+		return nil, fmt.Errorf("DoXML failed: %w", err)
 	}
 	return &resp, nil
 }
@@ -103,6 +117,8 @@ func (s *BatchGeocodingService) BatchGeocoderStatus(
 	if req.RequestID == "" {
 		return nil, fmt.Errorf("InvalidArgument, requestID can not be empty")
 	}
+	ctx, op := s.Client.startOperation(ctx, "BatchGeocoderStatus")
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse(fmt.Sprintf("jobs/%s", req.RequestID))
 	if err != nil {
 		return nil, err
@@ -113,7 +129,7 @@ func (s *BatchGeocodingService) BatchGeocoderStatus(
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp BatchGeocoderResponse
 	if err := s.Client.DoXML(r, &resp); err != nil {
@@ -132,17 +148,19 @@ func (s *BatchGeocodingService) BatchGeocoderDownload(
 	ctx context.Context,
 	req *BatchGeocoderDownloadRequest,
 	w io.Writer,
-) error {
+) (err error) {
 	if req.RequestID == "" {
 		return fmt.Errorf("InvalidArgument, requestID can not be empty")
 	}
+	ctx, op := s.Client.startOperation(ctx, "BatchGeocoderDownload")
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse(fmt.Sprintf("jobs/%s/result", req.RequestID))
 	if err != nil {
 		return err
 	}
 	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, "", nil)
 	if err != nil {
-		return fmt.Errorf("unable to create get request: %v", err)
+		return fmt.Errorf("unable to create get request: %w", err)
 	}
 	return s.Client.DoXML(r, w)
 }
@@ -0,0 +1,63 @@
+package geocodingsearchv7
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Browse searches for places around a position, filtered by categories, chains or name rather than by a free text
+// query, e.g. the truck stops closest to a position on a route. Results are sorted by distance from the position.
+// See https://developer.here.com/documentation/geocoding-search-api/api-reference-swagger.html
+// for more details.
+func (s *BrowseService) Browse(
+	ctx context.Context,
+	req *BrowseRequest,
+) (_ *BrowseResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "Browse")
+	defer func() { op.End(err) }()
+	u, err := s.URL.Parse("browse")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GeoPosition == nil {
+		return nil, fmt.Errorf("InvalidArgument, GeoPosition must be provided")
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("InvalidArgument, Limit must not be negative")
+	}
+	categories := make([]string, 0, len(req.Categories))
+	for _, category := range req.Categories {
+		if err := category.Validate(); err != nil {
+			return nil, fmt.Errorf("InvalidArgument, %w", err)
+		}
+		categories = append(categories, string(category))
+	}
+
+	values := make(url.Values)
+	if err := addSearchParameters(values, req.GeoPosition, req.Area, req.Limit, req.Lang); err != nil {
+		return nil, err
+	}
+	if len(categories) > 0 {
+		values.Add("categories", strings.Join(categories, ","))
+	}
+	if len(req.Chains) > 0 {
+		values.Add("chains", strings.Join(req.Chains, ","))
+	}
+	if req.Name != "" {
+		values.Add("name", req.Name)
+	}
+
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %w", err)
+	}
+	var resp BrowseResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,121 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+const browseResponse = `{"items": [{
+	"title": "Rasta Järna",
+	"id": "here:pds:place:752u6fbb-0f1e2d3c4b5a69788796a5b4c3d2e1f0",
+	"resultType": "place",
+	"address": {"label": "Rasta Järna, E4, 153 91 Järna, Sverige", "countryCode": "SWE", "city": "Järna"},
+	"position": {"lat": 59.0935, "lng": 17.5683},
+	"access": [{"lat": 59.0933, "lng": 17.5679}],
+	"distance": 28140,
+	"categories": [
+		{"id": "400-4300-0202", "name": "Motorway Service Rest Area", "primary": true},
+		{"id": "700-7600-0116", "name": "Petrol/Gasoline Station"}
+	],
+	"contacts": [{
+		"phone": [{"value": "+4685510000"}],
+		"www": [{"value": "https://www.rasta.se", "categories": [{"id": "400-4300-0202"}]}]
+	}],
+	"openingHours": [{
+		"text": ["Mon-Sun: 06:00 - 22:00"],
+		"isOpen": true,
+		"structured": [{"start": "T060000", "duration": "PT16H00M", "recurrence": "FREQ:DAILY;BYDAY:MO,TU,WE,TH,FR,SA,SU"}]
+	}]
+}]}`
+
+func TestBrowseService_Browse(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: browseResponse}
+	client := geocodingsearchv7.NewClient(&mock)
+	got, err := client.Browse.Browse(context.Background(), &geocodingsearchv7.BrowseRequest{
+		GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+		Categories:  geocodingsearchv7.TruckParkingCategories,
+		Chains:      []string{"1136", "2203"},
+		Name:        "Rasta",
+		Area:        geocodingsearchv7.InCountries("SWE"),
+		Limit:       20,
+		Lang:        []string{"sv-SE"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	request := mock.requests[0]
+	assert.Equal(t, request.URL.Host, "browse.search.hereapi.com")
+	assert.Equal(t, request.URL.Path, "/v1/browse")
+	query := request.URL.Query()
+	assert.Equal(t, query.Get("at"), "59.33749,18.06367")
+	assert.Equal(t, query.Get("categories"), "700-7900-0323,400-4300-0201,400-4300-0200,400-4300-0202")
+	assert.Equal(t, query.Get("chains"), "1136,2203")
+	assert.Equal(t, query.Get("name"), "Rasta")
+	assert.Equal(t, query.Get("in"), "countryCode:SWE")
+	assert.Equal(t, query.Get("limit"), "20")
+	assert.Equal(t, query.Get("lang"), "sv-SE")
+
+	assert.Equal(t, len(got.Items), 1)
+	item := got.Items[0]
+	assert.Equal(t, item.Distance, 28140)
+	assert.Equal(t, item.Categories[0].ID, geocodingsearchv7.CategoryMotorwayServiceArea)
+	assert.Assert(t, item.Categories[0].Primary)
+	assert.Assert(t, item.HasCategory(geocodingsearchv7.CategoryRestArea))
+	assert.Assert(t, item.HasCategory(geocodingsearchv7.CategoryFuelingStation))
+	assert.DeepEqual(t, item.Contacts, []geocodingsearchv7.Contact{{
+		Phone: []geocodingsearchv7.ContactInformation{{Value: "+4685510000"}},
+		WWW: []geocodingsearchv7.ContactInformation{{
+			Value:      "https://www.rasta.se",
+			Categories: []geocodingsearchv7.CategoryReference{{ID: geocodingsearchv7.CategoryMotorwayServiceArea}},
+		}},
+	}})
+	assert.DeepEqual(t, item.OpeningHours, []geocodingsearchv7.OpeningHours{{
+		Text:   []string{"Mon-Sun: 06:00 - 22:00"},
+		IsOpen: true,
+		Structured: []geocodingsearchv7.StructuredOpeningHours{{
+			Start:      "T060000",
+			Duration:   "PT16H00M",
+			Recurrence: "FREQ:DAILY;BYDAY:MO,TU,WE,TH,FR,SA,SU",
+		}},
+	}})
+}
+
+func TestBrowseService_Browse_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	at := &geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	for _, tt := range []struct {
+		name    string
+		request geocodingsearchv7.BrowseRequest
+		errStr  string
+	}{
+		{
+			name:    "missing position",
+			request: geocodingsearchv7.BrowseRequest{Categories: []geocodingsearchv7.CategoryID{"700-7900-0323"}},
+			errStr:  "InvalidArgument, GeoPosition must be provided",
+		},
+		{
+			name:    "invalid category",
+			request: geocodingsearchv7.BrowseRequest{GeoPosition: at, Categories: []geocodingsearchv7.CategoryID{"700-7900"}},
+			errStr:  `InvalidArgument, invalid category "700-7900"`,
+		},
+		{
+			name:    "negative limit",
+			request: geocodingsearchv7.BrowseRequest{GeoPosition: at, Limit: -1},
+			errStr:  "InvalidArgument, Limit must not be negative",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := RawResponseMock{statusCode: http.StatusOK, body: browseResponse}
+			client := geocodingsearchv7.NewClient(&mock)
+			_, err := client.Browse.Browse(context.Background(), &tt.request)
+			assert.Error(t, err, tt.errStr)
+			assert.Equal(t, len(mock.requests), 0)
+		})
+	}
+}
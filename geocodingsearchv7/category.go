@@ -0,0 +1,107 @@
+package geocodingsearchv7
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CategoryID identifies a category of the HERE category system, with the three levels of the hierarchy separated
+// by dashes, e.g. "700-7600-0116". Trailing levels of zeros, as in "700-7600-0000", identify a whole branch of the
+// hierarchy.
+type CategoryID string
+
+// Categories relevant to road freight. See the HERE category system for the full list.
+const (
+	// CategoryBusinessAndServices contains all business and service places, e.g. fueling stations.
+	CategoryBusinessAndServices CategoryID = "700-0000-0000"
+	// CategoryFuelingStation contains petrol, EV charging and other fueling stations.
+	CategoryFuelingStation CategoryID = "700-7600-0000"
+	CategoryPetrolStation  CategoryID = "700-7600-0116"
+	CategoryEVCharging     CategoryID = "700-7600-0322"
+	// CategoryTruckServices contains truck dealers, repair shops and truck stops.
+	CategoryTruckServices   CategoryID = "700-7900-0000"
+	CategoryTruckDealership CategoryID = "700-7900-0131"
+	CategoryTruckRepair     CategoryID = "700-7900-0132"
+	CategoryTruckStop       CategoryID = "700-7900-0323"
+	// CategoryRestArea contains rest areas along motorways and main roads.
+	CategoryRestArea            CategoryID = "400-4300-0000"
+	CategoryParkingAndRestrooms CategoryID = "400-4300-0200"
+	CategoryParkingOnlyRestArea CategoryID = "400-4300-0201"
+	CategoryMotorwayServiceArea CategoryID = "400-4300-0202"
+	// CategoryParking contains parking garages and lots.
+	CategoryParking       CategoryID = "800-8500-0000"
+	CategoryParkingGarage CategoryID = "800-8500-0177"
+	CategoryParkingLot    CategoryID = "800-8500-0178"
+)
+
+// TruckParkingCategories are the categories of places where trucks can typically park overnight. Pass them as the
+// categories of a BrowseRequest, or check results with PlaceItem.HasCategory.
+var TruckParkingCategories = []CategoryID{
+	CategoryTruckStop,
+	CategoryParkingOnlyRestArea,
+	CategoryParkingAndRestrooms,
+	CategoryMotorwayServiceArea,
+}
+
+var categoryNames = map[CategoryID]string{
+	CategoryBusinessAndServices: "Business and Services",
+	CategoryFuelingStation:      "Fueling Station",
+	CategoryPetrolStation:       "Petrol/Gasoline Station",
+	CategoryEVCharging:          "EV Charging Station",
+	CategoryTruckServices:       "Truck/Semi Dealer/Services",
+	CategoryTruckDealership:     "Truck Dealership",
+	CategoryTruckRepair:         "Truck Repair/Service",
+	CategoryTruckStop:           "Truck Stop/Plaza",
+	CategoryRestArea:            "Rest Area",
+	CategoryParkingAndRestrooms: "Parking and Restroom Only Rest Area",
+	CategoryParkingOnlyRestArea: "Parking Only Rest Area",
+	CategoryMotorwayServiceArea: "Motorway Service Rest Area",
+	CategoryParking:             "Parking",
+	CategoryParkingGarage:       "Parking Garage/Parking House",
+	CategoryParkingLot:          "Parking Lot",
+}
+
+// Name returns the English name of a category of the catalog in this package, or an empty string for other
+// categories. Results have the name of their categories in the language of the request.
+func (c CategoryID) Name() string {
+	return categoryNames[c]
+}
+
+// Validate checks that the category has the format of the HERE category system, e.g. "700-7600-0116".
+func (c CategoryID) Validate() error {
+	levels := strings.Split(string(c), "-")
+	if len(levels) != 3 || !isDigits(levels[0], 3) || !isDigits(levels[1], 4) || !isDigits(levels[2], 4) {
+		return fmt.Errorf("invalid category %q", string(c))
+	}
+	return nil
+}
+
+// IsWithin reports whether the category is the parent category, or one of its subcategories. For example,
+// CategoryPetrolStation is within CategoryFuelingStation and CategoryBusinessAndServices.
+func (c CategoryID) IsWithin(parent CategoryID) bool {
+	if c.Validate() != nil || parent.Validate() != nil {
+		return c == parent
+	}
+	levels, parentLevels := strings.Split(string(c), "-"), strings.Split(string(parent), "-")
+	for i, level := range parentLevels {
+		if i > 0 && strings.Trim(level, "0") == "" {
+			return true
+		}
+		if levels[i] != level {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package geocodingsearchv7_test
+
+import (
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+func TestCategoryID_IsWithin(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		category geocodingsearchv7.CategoryID
+		parent   geocodingsearchv7.CategoryID
+		expected bool
+	}{
+		{category: geocodingsearchv7.CategoryTruckStop, parent: geocodingsearchv7.CategoryTruckStop, expected: true},
+		{category: geocodingsearchv7.CategoryTruckStop, parent: geocodingsearchv7.CategoryTruckServices, expected: true},
+		{
+			category: geocodingsearchv7.CategoryPetrolStation,
+			parent:   geocodingsearchv7.CategoryBusinessAndServices,
+			expected: true,
+		},
+		{category: geocodingsearchv7.CategoryTruckServices, parent: geocodingsearchv7.CategoryTruckStop},
+		{category: geocodingsearchv7.CategoryTruckStop, parent: geocodingsearchv7.CategoryFuelingStation},
+		{category: geocodingsearchv7.CategoryParkingLot, parent: geocodingsearchv7.CategoryRestArea},
+		{category: "custom", parent: "custom", expected: true},
+		{category: "700-7900-0323", parent: "700"},
+	} {
+		tt := tt
+		t.Run(string(tt.category)+" in "+string(tt.parent), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.category.IsWithin(tt.parent), tt.expected)
+		})
+	}
+}
+
+func TestCategoryID_Validate(t *testing.T) {
+	t.Parallel()
+	assert.NilError(t, geocodingsearchv7.CategoryTruckStop.Validate())
+	assert.NilError(t, geocodingsearchv7.CategoryBusinessAndServices.Validate())
+	assert.Error(t, geocodingsearchv7.CategoryID("700-7900").Validate(), `invalid category "700-7900"`)
+	assert.Error(t, geocodingsearchv7.CategoryID("700-79OO-0000").Validate(), `invalid category "700-79OO-0000"`)
+	assert.Equal(t, geocodingsearchv7.CategoryTruckStop.Name(), "Truck Stop/Plaza")
+	assert.Equal(t, geocodingsearchv7.CategoryID("100-1000-0000").Name(), "")
+}
+
+func TestCategoryID_Name(t *testing.T) {
+	t.Parallel()
+	// The IDs are those of the HERE place categories, and not derived from the names.
+	for _, tt := range []struct {
+		category geocodingsearchv7.CategoryID
+		id       string
+		name     string
+	}{
+		{category: geocodingsearchv7.CategoryTruckStop, id: "700-7900-0323", name: "Truck Stop/Plaza"},
+		{category: geocodingsearchv7.CategoryRestArea, id: "400-4300-0000", name: "Rest Area"},
+		{
+			category: geocodingsearchv7.CategoryParkingAndRestrooms,
+			id:       "400-4300-0200",
+			name:     "Parking and Restroom Only Rest Area",
+		},
+		{category: geocodingsearchv7.CategoryParkingOnlyRestArea, id: "400-4300-0201", name: "Parking Only Rest Area"},
+		{
+			category: geocodingsearchv7.CategoryMotorwayServiceArea,
+			id:       "400-4300-0202",
+			name:     "Motorway Service Rest Area",
+		},
+		{category: geocodingsearchv7.CategoryParkingLot, id: "800-8500-0178", name: "Parking Lot"},
+	} {
+		tt := tt
+		t.Run(tt.id, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, string(tt.category), tt.id)
+			assert.Equal(t, tt.category.Name(), tt.name)
+		})
+	}
+}
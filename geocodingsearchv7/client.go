@@ -1,23 +1,18 @@
 package geocodingsearchv7
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"encoding/xml"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-)
 
-const (
-	userAgent = "einride/here-go"
+	"go.einride.tech/here/internal/core"
+	"go.einride.tech/here/telemetry"
 )
 
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+// HTTPClient sends HTTP requests on behalf of the Client.
+type HTTPClient = core.HTTPClient
 
 // GeocodingService handles communication with geocoding-related methods of the v7 HERE API.
 type GeocodingService service
@@ -25,12 +20,26 @@ type GeocodingService service
 // ReverseGeocodingService handles communication with reverse geocoding-related methods of the v7 HERE API.
 type ReverseGeocodingService service
 
+// AutosuggestService handles communication with autosuggest-related methods of the v7 HERE API.
+type AutosuggestService service
+
+// DiscoverService handles communication with discover-related methods of the v7 HERE API.
+type DiscoverService service
+
+// BrowseService handles communication with browse-related methods of the v7 HERE API.
+type BrowseService service
+
+// LookupService handles communication with lookup-related methods of the v7 HERE API.
+type LookupService service
+
 // BatchGeocodingService handles communication with batch geocoder-related methods of the v7 HERE API.
 type BatchGeocodingService service
 
 type Client struct {
 	// HTTP client used to communicate with the API.
 	client HTTPClient
+	// Tracer to report operations to, if any.
+	tracer telemetry.Tracer
 
 	UserAgent string
 
@@ -40,6 +49,14 @@ type Client struct {
 	ReverseGeocoding *ReverseGeocodingService
 	// BatchGeocoding service
 	BatchGeocoding *BatchGeocodingService
+	// Autosuggest service
+	Autosuggest *AutosuggestService
+	// Discover service
+	Discover *DiscoverService
+	// Browse service
+	Browse *BrowseService
+	// Lookup service
+	Lookup *LookupService
 }
 
 type service struct {
@@ -50,40 +67,43 @@ type service struct {
 
 // A ResponseError reports the error caused by an API request.
 type ResponseError struct {
-	// HTTP response that caused this error
+	// HTTP response that caused this error. Nil if the body of the response is not a HERE error.
 	Response *HereErrorResponse
 	// The HTTP body of the error response
 	HTTPBody string
 	// The HTTP status code of the response
 	HTTPStatusCode int
+	// Retries is the number of times the request was retried before this response, when sent through a
+	// retry.Client.
+	Retries int
 }
 
 func (r *ResponseError) Error() string {
-	return fmt.Sprintf(
-		"Title: %v, Status: %d, Code: %v, Cause: %v, Action: %v",
-		r.Response.Title,
-		r.Response.Status,
-		r.Response.Code,
-		r.Response.Cause,
-		r.Response.Action,
-	)
+	return core.ErrorMessage((*core.HereError)(r.Response), r.HTTPBody, r.HTTPStatusCode)
+}
+
+// Is reports whether the error is classified by target, one of the sentinel errors such as ErrRateLimited.
+func (r *ResponseError) Is(target error) bool {
+	return core.IsStatusError(r.HTTPStatusCode, target)
 }
 
 // NewClient returns a new HERE API Client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient HTTPClient) *Client {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	c := &Client{client: httpClient, UserAgent: userAgent}
-	geocodingURL, _ := url.Parse("https://geocode.search.hereapi.com/v1/")
-	c.Geocoding = &GeocodingService{URL: geocodingURL, Client: c}
-	reverseGeocodingURL, _ := url.Parse("https://revgeocode.search.hereapi.com/v1/")
-	c.ReverseGeocoding = &ReverseGeocodingService{URL: reverseGeocodingURL, Client: c}
-	batchGeocoderURL, _ := url.Parse("https://batch.geocoder.ls.hereapi.com/6.2/")
-	c.BatchGeocoding = &BatchGeocodingService{URL: batchGeocoderURL, Client: c}
+func NewClient(httpClient HTTPClient, opts ...Option) *Client {
+	o := core.NewOptions(httpClient, opts)
+	c := &Client{client: o.Client(), tracer: o.Tracer, UserAgent: o.UserAgent}
+	c.Geocoding = &GeocodingService{URL: o.ServiceURL("https://geocode.search.hereapi.com/v1/"), Client: c}
+	c.ReverseGeocoding = &ReverseGeocodingService{
+		URL:    o.ServiceURL("https://revgeocode.search.hereapi.com/v1/"),
+		Client: c,
+	}
+	c.BatchGeocoding = &BatchGeocodingService{URL: o.ServiceURL("https://batch.geocoder.ls.hereapi.com/6.2/"), Client: c}
+	c.Autosuggest = &AutosuggestService{URL: o.ServiceURL("https://autosuggest.search.hereapi.com/v1/"), Client: c}
+	c.Discover = &DiscoverService{URL: o.ServiceURL("https://discover.search.hereapi.com/v1/"), Client: c}
+	c.Browse = &BrowseService{URL: o.ServiceURL("https://browse.search.hereapi.com/v1/"), Client: c}
+	c.Lookup = &LookupService{URL: o.ServiceURL("https://lookup.search.hereapi.com/v1/"), Client: c}
 	return c
 }
 
@@ -98,138 +118,58 @@ func (c *Client) NewRequest(
 	rawQuery string,
 	body []byte,
 ) (*http.Request, error) {
-	if len(rawQuery) > 0 {
-		u.RawQuery = rawQuery
-	}
-	var r io.Reader
-	if len(body) > 0 {
-		r = bytes.NewReader(body)
-	}
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
-	if err != nil {
-		return nil, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
-	}
-	return req, nil
+	return core.NewRequest(ctx, c.UserAgent, u, method, rawQuery, body)
+}
+
+// startOperation starts an operation, reported to the tracer and metrics of the Client. Requests created with the
+// returned context are reported as part of the operation, which must be ended with the result of the service call.
+func (c *Client) startOperation(
+	ctx context.Context,
+	name string,
+	attributes ...telemetry.Attribute,
+) (context.Context, *core.Operation) {
+	return core.StartOperation(ctx, c.tracer, "geocodingsearchv7", name, attributes...)
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if rerr := resp.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
-	err = checkResponse(resp)
-	if err != nil {
-		return err
-	}
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-			if err != nil {
-				return fmt.Errorf("failed to json decode: %w", err)
-			}
-		}
-	}
-	return err
-}
-
-// checkResponse checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range.
-func checkResponse(r *http.Response) error {
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
-	}
-	buf := new(bytes.Buffer)
-	_, err := io.Copy(buf, r.Body)
-	if err != nil {
-		return err
-	}
-	var response HereErrorResponse
-	err = json.Unmarshal(buf.Bytes(), &response)
-	if err != nil {
-		return err
-	}
-	return &ResponseError{
-		Response:       &response,
-		HTTPBody:       buf.String(),
-		HTTPStatusCode: r.StatusCode,
-	}
+	return core.Do(c.client, req, v, core.DecodeJSON, newResponseError)
 }
 
 // DoXML sends an API request and returns the API response. The API response is XML decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) DoXML(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if rerr := resp.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
-	err = checkResponseXML(resp)
-	if err != nil {
-		return fmt.Errorf("checkResponse failed: %v, %v", resp.StatusCode, err)
-	}
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-			if err != nil {
-				return fmt.Errorf("copy body failed: %v", err)
-			}
-		} else {
-			data, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("read body: %v", err)
-			}
-			err = xml.Unmarshal(data, v)
-			if err != nil {
-				return fmt.Errorf("xml unmarshal failed: %w", err)
-			}
-		}
-	}
-	return err
+	return core.Do(c.client, req, v, core.DecodeXML, newResponseErrorXML)
 }
 
-// checkResponseXML checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range. It decodes the error as XML.
-func checkResponseXML(r *http.Response) error {
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
-	}
-	buf := new(bytes.Buffer)
-	_, err := io.Copy(buf, r.Body)
-	if err != nil {
-		return err
+// newResponseError returns the error of a response with a status code outside the 200 range.
+func newResponseError(r *http.Response, body []byte) error {
+	responseError := &ResponseError{
+		HTTPBody:       string(body),
+		HTTPStatusCode: r.StatusCode,
+		Retries:        core.Retries(r),
 	}
-	response := HereErrorResponse{}
-	err = xml.Unmarshal(buf.Bytes(), &response)
-	if err != nil {
-		return fmt.Errorf("failed unmarshal error: %w", err)
+	var response HereErrorResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		responseError.Response = &response
 	}
-	return &ResponseError{
-		Response:       &response,
-		HTTPBody:       buf.String(),
+	return responseError
+}
+
+// newResponseErrorXML returns the error of a response with a status code outside the 200 range, decoding the
+// error as XML.
+func newResponseErrorXML(r *http.Response, body []byte) error {
+	responseError := &ResponseError{
+		HTTPBody:       string(body),
 		HTTPStatusCode: r.StatusCode,
+		Retries:        core.Retries(r),
+	}
+	var response HereErrorResponse
+	if err := xml.Unmarshal(body, &response); err == nil {
+		responseError.Response = &response
 	}
+	return responseError
 }
@@ -0,0 +1,95 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+type RawResponseMock struct {
+	statusCode int
+	body       string
+	requests   []*http.Request
+}
+
+func (r *RawResponseMock) Do(req *http.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+	return &http.Response{
+		StatusCode: r.statusCode,
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestClient_ResponseError(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		mock     RawResponseMock
+		errStr   string
+		sentinel error
+	}{
+		{
+			name: "here error",
+			mock: RawResponseMock{
+				statusCode: http.StatusBadRequest,
+				body:       `{"title":"Illegal input","status":400,"cause":"q is empty","action":"set q"}`,
+			},
+			errStr:   "Title: Illegal input, Status: 400, Code: , Cause: q is empty, Action: set q",
+			sentinel: geocodingsearchv7.ErrBadRequest,
+		},
+		{
+			name:     "non-json body",
+			mock:     RawResponseMock{statusCode: http.StatusBadGateway, body: "<html>Bad Gateway</html>"},
+			errStr:   "Response: <html>Bad Gateway</html> StatusCode: 502",
+			sentinel: geocodingsearchv7.ErrServiceUnavailable,
+		},
+		{
+			name:   "empty json body",
+			mock:   RawResponseMock{statusCode: http.StatusInternalServerError, body: "{}"},
+			errStr: "Response: {} StatusCode: 500",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := geocodingsearchv7.NewClient(&tt.mock)
+			q := "Stockholm"
+			_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q})
+			assert.Error(t, err, tt.errStr)
+			var responseError *geocodingsearchv7.ResponseError
+			assert.Assert(t, errors.As(err, &responseError))
+			assert.Equal(t, responseError.HTTPStatusCode, tt.mock.statusCode)
+			assert.Equal(t, responseError.HTTPBody, tt.mock.body)
+			if tt.sentinel != nil {
+				assert.Assert(t, errors.Is(err, tt.sentinel))
+			}
+		})
+	}
+}
+
+func TestClient_WithBaseURL(t *testing.T) {
+	t.Parallel()
+	baseURL, err := url.Parse("http://127.0.0.1:8080/here")
+	assert.NilError(t, err)
+	mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items":[]}`}
+	q := "Stockholm"
+	client := geocodingsearchv7.NewClient(
+		nil,
+		geocodingsearchv7.WithHTTPClient(&mock),
+		geocodingsearchv7.WithBaseURL(baseURL),
+		geocodingsearchv7.WithUserAgent("test"),
+	)
+	_, err = client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	assert.Equal(t, mock.requests[0].URL.String(), "http://127.0.0.1:8080/here/v1/geocode?q=Stockholm")
+	assert.Equal(t, mock.requests[0].Header.Get("User-Agent"), "test")
+}
@@ -0,0 +1,50 @@
+package geocodingsearchv7
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Discover searches for places matching a free text query, such as a name, category or chain, near a position or
+// within an area. Unlike Geocoding, it returns several places, with their categories, contacts and opening hours.
+// See https://developer.here.com/documentation/geocoding-search-api/api-reference-swagger.html
+// for more details.
+func (s *DiscoverService) Discover(
+	ctx context.Context,
+	req *DiscoverRequest,
+) (_ *DiscoverResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "Discover")
+	defer func() { op.End(err) }()
+	u, err := s.URL.Parse("discover")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Q == "" {
+		return nil, fmt.Errorf("InvalidArgument, Q must be provided")
+	}
+	if req.GeoPosition == nil && !req.Area.hasShape() {
+		return nil, fmt.Errorf("InvalidArgument, %w", errMissingPosition)
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("InvalidArgument, Limit must not be negative")
+	}
+
+	values := make(url.Values)
+	values.Add("q", req.Q)
+	if err := addSearchParameters(values, req.GeoPosition, req.Area, req.Limit, req.Lang); err != nil {
+		return nil, err
+	}
+
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %w", err)
+	}
+	var resp DiscoverResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,77 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+func TestDiscoverService_Discover(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": [{
+		"title": "Circle K Truck",
+		"id": "here:pds:place:752u6fbb-4b7e1b1b0c2b4c2e9b1c2d3e4f5a6b7c",
+		"resultType": "place",
+		"position": {"lat": 59.2891, "lng": 17.9312},
+		"distance": 5230,
+		"categories": [{"id": "700-7900-0323", "name": "Truck Stop/Plaza", "primary": true}],
+		"chains": [{"id": "1136", "name": "Circle K"}]
+	}]}`}
+	client := geocodingsearchv7.NewClient(&mock)
+	got, err := client.Discover.Discover(context.Background(), &geocodingsearchv7.DiscoverRequest{
+		Q:           "truck stop",
+		GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+		Limit:       10,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	request := mock.requests[0]
+	assert.Equal(t, request.URL.Host, "discover.search.hereapi.com")
+	assert.Equal(t, request.URL.Path, "/v1/discover")
+	assert.Equal(t, request.URL.RawQuery, "at=59.33749%2C18.06367&limit=10&q=truck+stop")
+	assert.Equal(t, len(got.Items), 1)
+	item := got.Items[0]
+	assert.Equal(t, item.ResultType, geocodingsearchv7.ResultTypePlace)
+	assert.Equal(t, item.Distance, 5230)
+	assert.DeepEqual(t, item.Chains, []geocodingsearchv7.Chain{{ID: "1136", Name: "Circle K"}})
+	assert.Assert(t, item.HasCategory(geocodingsearchv7.CategoryTruckServices))
+	assert.Assert(t, !item.HasCategory(geocodingsearchv7.CategoryFuelingStation))
+}
+
+func TestDiscoverService_Discover_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name    string
+		request geocodingsearchv7.DiscoverRequest
+		errStr  string
+	}{
+		{
+			name:    "missing query",
+			request: geocodingsearchv7.DiscoverRequest{GeoPosition: &geocodingsearchv7.GeoWaypoint{}},
+			errStr:  "InvalidArgument, Q must be provided",
+		},
+		{
+			name:    "missing search context",
+			request: geocodingsearchv7.DiscoverRequest{Q: "truck stop"},
+			errStr:  "InvalidArgument, either GeoPosition or Area with a circle or bounding box must be provided",
+		},
+		{
+			name:    "only countries",
+			request: geocodingsearchv7.DiscoverRequest{Q: "truck stop", Area: geocodingsearchv7.InCountries("SWE")},
+			errStr:  "InvalidArgument, either GeoPosition or Area with a circle or bounding box must be provided",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+			client := geocodingsearchv7.NewClient(&mock)
+			_, err := client.Discover.Discover(context.Background(), &tt.request)
+			assert.Error(t, err, tt.errStr)
+			assert.Equal(t, len(mock.requests), 0)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package geocodingsearchv7
+
+import "go.einride.tech/here/internal/core"
+
+// Sentinel errors classifying the errors of the Client, see ResponseError.Is.
+var (
+	ErrUnauthorized       = core.ErrUnauthorized
+	ErrForbidden          = core.ErrForbidden
+	ErrRateLimited        = core.ErrRateLimited
+	ErrBadRequest         = core.ErrBadRequest
+	ErrNoRoute            = core.ErrNoRoute
+	ErrServiceUnavailable = core.ErrServiceUnavailable
+)
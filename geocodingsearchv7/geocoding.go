@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // Geocoding allows forward geocoding of addresses and geo-positions.
@@ -14,6 +15,8 @@ func (s *GeocodingService) Geocoding(
 	ctx context.Context,
 	req *GeocodingRequest,
 ) (_ *GeocodingResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "Geocoding")
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("geocode")
 	if err != nil {
 		return nil, err
@@ -23,23 +26,40 @@ func (s *GeocodingService) Geocoding(
 		return nil, fmt.Errorf("InvalidArgument, either Queries or QQ must be provided")
 	}
 
-	values := make(url.Values)
-	if req.GeoPosition != nil {
-		values.Add("at", fmt.Sprintf("%v,%v", req.GeoPosition.Lat, req.GeoPosition.Long))
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("InvalidArgument, Limit must not be negative")
 	}
+
+	values := make(url.Values)
 	if req.Q != nil {
 		values.Add("q", *req.Q)
 	}
 	if req.Address != nil {
 		values.Add("qq", FormatQualifiedQuery(*req.Address))
 	}
-	if req.In != nil {
-		values.Add("in", *req.In)
+	if err := addSearchParameters(values, req.GeoPosition, req.Area, req.Limit, req.Lang); err != nil {
+		return nil, err
+	}
+	if err := addDeprecatedIn(values, req.In, req.Area); err != nil {
+		return nil, err
+	}
+	if len(req.Types) > 0 {
+		types := make([]string, 0, len(req.Types))
+		for _, t := range req.Types {
+			types = append(types, string(t))
+		}
+		values.Add("types", strings.Join(types, ","))
+	}
+	if len(req.Show) > 0 {
+		values.Add("show", joinShow(req.Show))
+	}
+	if req.PoliticalView != "" {
+		values.Add("politicalView", req.PoliticalView)
 	}
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp GeocodingResponse
 	if err := s.Client.Do(r, &resp); err != nil {
@@ -106,3 +106,70 @@ func TestGeocodingService_FormatQualifiedQuery(t *testing.T) {
 		},
 	)
 }
+
+func TestGeocodingService_Geocoding_Options(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": [{
+		"title": "Regeringsgatan 65, 111 56 Stockholm, Sverige",
+		"id": "here:af:streetsection:Vn2Mku7Kb5X7Xp-vK2GQeB:CgcIBCDn3ekZEAEaAjY1",
+		"resultType": "houseNumber",
+		"address": {"label": "Regeringsgatan 65, 111 56 Stockholm, Sverige", "countryCode": "SWE"},
+		"position": {"lat": 59.33749, "lng": 18.06367},
+		"timeZone": {"name": "Europe/Stockholm", "utcOffset": "+01:00"},
+		"streetInfo": [{"baseName": "Regerings", "streetType": "gatan", "streetTypeAttached": true, "language": "sv"}],
+		"countryInfo": {"alpha2": "SE", "alpha3": "SWE"},
+		"parsing": {
+			"street": [{"start": 0, "end": 14, "value": "Regeringsgatan"}],
+			"houseNumber": [{"start": 15, "end": 17, "value": "65"}],
+			"city": [{"start": 19, "end": 28, "value": "Stockholm"}]
+		}
+	}]}`}
+	client := geocodingsearchv7.NewClient(&mock)
+	q := "Regeringsgatan 65, Stockholm"
+	got, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{
+		Q:     &q,
+		Limit: 3,
+		Lang:  []string{"sv-SE"},
+		Types: []geocodingsearchv7.GeocodingType{
+			geocodingsearchv7.GeocodingTypeHouseNumber,
+			geocodingsearchv7.GeocodingTypeStreet,
+		},
+		Show: []geocodingsearchv7.Show{
+			geocodingsearchv7.ShowTimeZone,
+			geocodingsearchv7.ShowStreetInfo,
+			geocodingsearchv7.ShowCountryInfo,
+			geocodingsearchv7.ShowParsing,
+		},
+		PoliticalView: "SWE",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	query := mock.requests[0].URL.Query()
+	assert.Equal(t, query.Get("q"), q)
+	assert.Equal(t, query.Get("limit"), "3")
+	assert.Equal(t, query.Get("lang"), "sv-SE")
+	assert.Equal(t, query.Get("types"), "houseNumber,street")
+	assert.Equal(t, query.Get("show"), "tz,streetInfo,countryInfo,parsing")
+	assert.Equal(t, query.Get("politicalView"), "SWE")
+
+	assert.Equal(t, len(got.Items), 1)
+	item := got.Items[0]
+	assert.DeepEqual(t, item.TimeZone, &geocodingsearchv7.TimeZone{Name: "Europe/Stockholm", UTCOffset: "+01:00"})
+	assert.Equal(t, item.StreetInfo[0].BaseName, "Regerings")
+	assert.DeepEqual(t, item.CountryInfo, &geocodingsearchv7.CountryInfo{Alpha2: "SE", Alpha3: "SWE"})
+	assert.DeepEqual(t, item.Parsing, &geocodingsearchv7.Parsing{
+		Street:      []geocodingsearchv7.ParsingMatch{{Start: 0, End: 14, Value: "Regeringsgatan"}},
+		HouseNumber: []geocodingsearchv7.ParsingMatch{{Start: 15, End: 17, Value: "65"}},
+		City:        []geocodingsearchv7.ParsingMatch{{Start: 19, End: 28, Value: "Stockholm"}},
+	})
+}
+
+func TestGeocodingService_Geocoding_NegativeLimit(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+	client := geocodingsearchv7.NewClient(&mock)
+	q := "Stockholm"
+	_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q, Limit: -1})
+	assert.Error(t, err, "InvalidArgument, Limit must not be negative")
+	assert.Equal(t, len(mock.requests), 0)
+}
@@ -0,0 +1,59 @@
+package geocodingsearchv7
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Lookup returns the item with the identifier of a previous result, e.g. to refresh a stored address without
+// geocoding its text again.
+// See https://developer.here.com/documentation/geocoding-search-api/api-reference-swagger.html
+// for more details.
+func (s *LookupService) Lookup(
+	ctx context.Context,
+	req *LookupRequest,
+) (_ *LookupResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "Lookup")
+	defer func() { op.End(err) }()
+	u, err := s.URL.Parse("lookup")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ID == "" {
+		return nil, fmt.Errorf("InvalidArgument, ID must be provided")
+	}
+
+	values := make(url.Values)
+	values.Add("id", req.ID)
+	if len(req.Lang) > 0 {
+		values.Add("lang", strings.Join(req.Lang, ","))
+	}
+	if len(req.Show) > 0 {
+		values.Add("show", joinShow(req.Show))
+	}
+	if req.PoliticalView != "" {
+		values.Add("politicalView", req.PoliticalView)
+	}
+
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %w", err)
+	}
+	var resp LookupResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func joinShow(show []Show) string {
+	values := make([]string, 0, len(show))
+	for _, s := range show {
+		values = append(values, string(s))
+	}
+	return strings.Join(values, ",")
+}
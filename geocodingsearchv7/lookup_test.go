@@ -0,0 +1,81 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	_ "time/tzdata" // Embed the time zone database, for TimeZone.Location.
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+func TestLookupService_Lookup(t *testing.T) {
+	t.Parallel()
+	mock := RawResponseMock{statusCode: http.StatusOK, body: `{
+		"title": "Regeringsgatan 65, 111 56 Stockholm, Sverige",
+		"id": "here:af:streetsection:Vn2Mku7Kb5X7Xp-vK2GQeB:CgcIBCDn3ekZEAEaAjY1",
+		"resultType": "houseNumber",
+		"houseNumberType": "PA",
+		"address": {"label": "Regeringsgatan 65, 111 56 Stockholm, Sverige", "countryCode": "SWE", "city": "Stockholm"},
+		"position": {"lat": 59.33749, "lng": 18.06367},
+		"timeZone": {"name": "Europe/Stockholm", "utcOffset": "+02:00"},
+		"streetInfo": [{"baseName": "Regerings", "streetType": "gatan", "streetTypeAttached": true, "language": "sv"}],
+		"countryInfo": {"alpha2": "SE", "alpha3": "SWE"}
+	}`}
+	client := geocodingsearchv7.NewClient(&mock)
+	got, err := client.Lookup.Lookup(context.Background(), &geocodingsearchv7.LookupRequest{
+		ID:            "here:af:streetsection:Vn2Mku7Kb5X7Xp-vK2GQeB:CgcIBCDn3ekZEAEaAjY1",
+		Lang:          []string{"sv-SE"},
+		Show:          []geocodingsearchv7.Show{geocodingsearchv7.ShowTimeZone, geocodingsearchv7.ShowStreetInfo},
+		PoliticalView: "SWE",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(mock.requests), 1)
+	request := mock.requests[0]
+	assert.Equal(t, request.URL.Host, "lookup.search.hereapi.com")
+	assert.Equal(t, request.URL.Path, "/v1/lookup")
+	query := request.URL.Query()
+	assert.Equal(t, query.Get("id"), "here:af:streetsection:Vn2Mku7Kb5X7Xp-vK2GQeB:CgcIBCDn3ekZEAEaAjY1")
+	assert.Equal(t, query.Get("lang"), "sv-SE")
+	assert.Equal(t, query.Get("show"), "tz,streetInfo")
+	assert.Equal(t, query.Get("politicalView"), "SWE")
+
+	assert.Equal(t, got.ResultType, geocodingsearchv7.ResultTypeHouseNumber)
+	assert.Equal(t, got.Address.City, "Stockholm")
+	assert.DeepEqual(t, got.TimeZone, &geocodingsearchv7.TimeZone{Name: "Europe/Stockholm", UTCOffset: "+02:00"})
+	location, err := got.TimeZone.Location()
+	assert.NilError(t, err)
+	assert.Equal(t, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC).In(location).Hour(), 14)
+	assert.DeepEqual(t, got.StreetInfo, []geocodingsearchv7.StreetInfo{
+		{BaseName: "Regerings", StreetType: "gatan", StreetTypeAttached: true, Language: "sv"},
+	})
+	assert.DeepEqual(t, got.CountryInfo, &geocodingsearchv7.CountryInfo{Alpha2: "SE", Alpha3: "SWE"})
+}
+
+func TestLookupService_Lookup_Errors(t *testing.T) {
+	t.Parallel()
+	t.Run("missing id", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.Lookup.Lookup(context.Background(), &geocodingsearchv7.LookupRequest{})
+		assert.Error(t, err, "InvalidArgument, ID must be provided")
+		assert.Equal(t, len(mock.requests), 0)
+	})
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{
+			statusCode: http.StatusNotFound,
+			body:       `{"title":"Id 'here:af:unknown' not found","status":404,"cause":"unknown id"}`,
+		}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.Lookup.Lookup(context.Background(), &geocodingsearchv7.LookupRequest{ID: "here:af:unknown"})
+		var responseError *geocodingsearchv7.ResponseError
+		assert.Assert(t, errors.As(err, &responseError))
+		assert.Equal(t, responseError.HTTPStatusCode, http.StatusNotFound)
+		assert.Equal(t, responseError.Response.Title, "Id 'here:af:unknown' not found")
+	})
+}
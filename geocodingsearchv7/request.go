@@ -13,12 +13,43 @@ type GeocodingRequest struct {
 	Q *string
 	// The address to search for. Works similar to free text query but separates parameters.
 	Address *AddressRequest
-	// Search within a geographic area.
+	// Search within a geographic area, e.g. InCountries("SWE").
 	// Results will be returned if they are located within the specified area.
-	// a country (or multiple countries), provided as comma-separated ISO 3166-1 alpha-3 country codes.
+	Area *SearchArea
+	// Search within a geographic area, in the format of the in parameter of the HERE API, e.g. "countryCode:SWE".
+	// Mutually exclusive with Area.
+	//
+	// Deprecated: Use Area, which is validated before the request is sent.
 	In *string
+	// Maximum number of results to be returned. Uses the default limit of the API if zero.
+	Limit int
+	// Preferred languages of the results, as BCP 47 language codes.
+	Lang []string
+	// Only return results of these types. Returns results of all types if empty.
+	Types []GeocodingType
+	// Additional fields to show in the results, e.g. ShowTimeZone or ShowParsing.
+	Show []Show
+	// The political view of the results for disputed territories, as an ISO 3166-1 alpha-3 country code.
+	// Uses the international view if empty.
+	PoliticalView string
 }
 
+// GeocodingType is a type of result to filter geocoding results by.
+type GeocodingType string
+
+const (
+	// GeocodingTypeAddress filters for addresses, i.e. house numbers and streets.
+	GeocodingTypeAddress     GeocodingType = "address"
+	GeocodingTypeHouseNumber GeocodingType = "houseNumber"
+	GeocodingTypeStreet      GeocodingType = "street"
+	// GeocodingTypeLocality filters for cities, districts and postal codes.
+	GeocodingTypeLocality   GeocodingType = "locality"
+	GeocodingTypeCity       GeocodingType = "city"
+	GeocodingTypePostalCode GeocodingType = "postalCode"
+	// GeocodingTypeArea filters for localities and administrative areas, e.g. states and countries.
+	GeocodingTypeArea GeocodingType = "area"
+)
+
 type AddressRequest struct {
 	// The id to recognize this address with, will be returned in result from HERE as recId
 	RecID string `json:"recId,omitempty"`
@@ -49,10 +80,16 @@ type GeoWaypointRequest struct {
 
 type ReverseGeocodingRequest struct {
 	// Specify the coordinates to perform a reverse geocoding. Returns the closest address given the coordinates.
+	// Either GeoPosition or Area with a circle or bounding box is required.
 	GeoPosition *GeoWaypoint
-	// Search within a geographic area.
+	// Search within a geographic area, e.g. InCountries("SWE"), or InCircle(position, 500) to return the addresses
+	// around the center of the circle instead of GeoPosition.
 	// Results will be returned if they are located within the specified area.
-	// a country (or multiple countries), provided as comma-separated ISO 3166-1 alpha-3 country codes.
+	Area *SearchArea
+	// Search within a geographic area, in the format of the in parameter of the HERE API, e.g. "countryCode:SWE".
+	// Mutually exclusive with Area. Does not replace GeoPosition.
+	//
+	// Deprecated: Use Area, which is validated before the request is sent.
 	In *string
 }
 
@@ -86,3 +123,83 @@ type QueryString struct {
 	// The country of where the free query refers to, in ISO 3166-1 alpha-3 format.
 	Country string
 }
+
+type AutosuggestRequest struct {
+	// Free text query, typically the partial input of a user. Example: "Regeringsg"
+	// Required.
+	Q string
+	// Specify the center of the search context expressed as coordinates.
+	// Either GeoPosition or Area with a circle or bounding box is required.
+	GeoPosition *GeoWaypoint
+	// Search within a geographic area, e.g. InCircle(position, 5000). Mutually exclusive with GeoPosition, unless
+	// the area only has countries, which filter the results but do not replace GeoPosition.
+	// Either GeoPosition or Area with a circle or bounding box is required.
+	Area *SearchArea
+	// Maximum number of results to be returned. Uses the default limit of the API if zero.
+	Limit int
+	// Preferred languages of the results, as BCP 47 language codes.
+	Lang []string
+}
+
+type DiscoverRequest struct {
+	// Free text query, e.g. a name, category or chain. Example: "truck stop"
+	// Required.
+	Q string
+	// Specify the center of the search context expressed as coordinates. Distances of results are relative to it.
+	// Either GeoPosition or Area with a circle or bounding box is required.
+	GeoPosition *GeoWaypoint
+	// Search within a geographic area, e.g. InCircle(position, 5000). Mutually exclusive with GeoPosition, unless
+	// the area only has countries, which filter the results but do not replace GeoPosition.
+	// Either GeoPosition or Area with a circle or bounding box is required.
+	Area *SearchArea
+	// Maximum number of results to be returned. Uses the default limit of the API if zero.
+	Limit int
+	// Preferred languages of the results, as BCP 47 language codes.
+	Lang []string
+}
+
+type BrowseRequest struct {
+	// Specify the center of the search context expressed as coordinates. Results are sorted by distance from it.
+	// Required.
+	GeoPosition *GeoWaypoint
+	// Only return places with one of the categories, or one of their subcategories.
+	Categories []CategoryID
+	// Only return places of one of the chains, by chain identifier.
+	Chains []string
+	// Only return places with a name containing this string.
+	Name string
+	// Search within a geographic area, e.g. InCountries("SWE", "NOR"). Must not have a circle or bounding box,
+	// since results are around GeoPosition.
+	Area *SearchArea
+	// Maximum number of results to be returned. Uses the default limit of the API if zero.
+	Limit int
+	// Preferred languages of the results, as BCP 47 language codes.
+	Lang []string
+}
+
+type LookupRequest struct {
+	// The identifier of the item to look up, e.g. the ID of a GeocodingItem.
+	// Required.
+	ID string
+	// Preferred languages of the result, as BCP 47 language codes.
+	Lang []string
+	// Additional fields to show in the result, e.g. ShowTimeZone.
+	Show []Show
+	// The political view of the result for disputed territories, as an ISO 3166-1 alpha-3 country code.
+	// Uses the international view if empty.
+	PoliticalView string
+}
+
+// Show is an additional field to show in results.
+type Show string
+
+const (
+	// ShowTimeZone shows the time zone of results.
+	ShowTimeZone Show = "tz"
+	// ShowStreetInfo shows the parts of the street names of results.
+	ShowStreetInfo Show = "streetInfo"
+	// ShowCountryInfo shows the ISO 3166-1 country codes of results.
+	ShowCountryInfo Show = "countryInfo"
+	// ShowParsing shows which parts of the query matched which fields of geocoding results.
+	ShowParsing Show = "parsing"
+)
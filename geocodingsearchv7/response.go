@@ -1,5 +1,7 @@
 package geocodingsearchv7
 
+import "time"
+
 type GeocodingResponse struct {
 	Items []GeocodingItem
 }
@@ -7,7 +9,7 @@ type GeocodingResponse struct {
 type GeocodingItem struct {
 	// A representative string for the result, for instance the name of a place, or a complete address.
 	Title string `json:"title,omitempty"`
-	// The identifier of the item. Its value can be used to retrieve the very same object using the /lookup endpoint.
+	// The identifier of the item. Its value can be used to retrieve the very same object with Lookup.
 	ID string `json:"id,omitempty"`
 	// HERE Geocoding and Search supports multiple location object types (place, street, locality, ...).
 	ResultType string `json:"resultType,omitempty"`
@@ -27,6 +29,14 @@ type GeocodingItem struct {
 	// This can be used by the customer application to accept or reject
 	// the results depending on how “expensive” is the mistake for their use case.
 	Scoring Scoring `json:"scoring,omitempty"`
+	// The time zone of the result. Only set with ShowTimeZone.
+	TimeZone *TimeZone `json:"timeZone,omitempty"`
+	// The parts of the street names of the result. Only set with ShowStreetInfo.
+	StreetInfo []StreetInfo `json:"streetInfo,omitempty"`
+	// The ISO 3166-1 codes of the country of the result. Only set with ShowCountryInfo.
+	CountryInfo *CountryInfo `json:"countryInfo,omitempty"`
+	// The parts of the query which matched the fields of the result. Only set with ShowParsing.
+	Parsing *Parsing `json:"parsing,omitempty"`
 }
 
 type ReverseGeocodingResponse struct {
@@ -36,7 +46,7 @@ type ReverseGeocodingResponse struct {
 type ReverseGeocodingItem struct {
 	// A representative string for the result, for instance the name of a place, or a complete address.
 	Title string `json:"title,omitempty"`
-	// The identifier of the item. Its value can be used to retrieve the very same object using the /lookup endpoint.
+	// The identifier of the item. Its value can be used to retrieve the very same object with Lookup.
 	ID string `json:"id,omitempty"`
 	// HERE Geocoding and Search supports multiple location object types (place, street, locality, ...).
 	ResultType string `json:"resultType,omitempty"`
@@ -161,3 +171,318 @@ type BatchGeocoderResponseRow struct {
 	State            string  `csv:"state"`
 	Country          string  `csv:"country"`
 }
+
+// ResultType is the type of location object of a result.
+type ResultType string
+
+const (
+	ResultTypePlace              ResultType = "place"
+	ResultTypeLocality           ResultType = "locality"
+	ResultTypeStreet             ResultType = "street"
+	ResultTypeIntersection       ResultType = "intersection"
+	ResultTypeAddressBlock       ResultType = "addressBlock"
+	ResultTypeHouseNumber        ResultType = "houseNumber"
+	ResultTypePostalCodePoint    ResultType = "postalCodePoint"
+	ResultTypeAdministrativeArea ResultType = "administrativeArea"
+	// ResultTypeCategoryQuery is a query suggestion for places of a category.
+	ResultTypeCategoryQuery ResultType = "categoryQuery"
+	// ResultTypeChainQuery is a query suggestion for places of a chain.
+	ResultTypeChainQuery ResultType = "chainQuery"
+)
+
+// IsQuery reports whether the result type is a query suggestion rather than a location.
+func (r ResultType) IsQuery() bool {
+	return r == ResultTypeCategoryQuery || r == ResultTypeChainQuery
+}
+
+type AutosuggestResponse struct {
+	Items []AutosuggestItem `json:"items"`
+	// Suggested completions of the terms of the query, e.g. "Regeringsgatan" for "Regeringsg".
+	QueryTerms []QueryTerm `json:"queryTerms,omitempty"`
+}
+
+type AutosuggestItem struct {
+	// A representative string for the result, for instance the name of a place, or a complete address.
+	Title string `json:"title,omitempty"`
+	// The identifier of the item. Its value can be used to retrieve the very same object with Lookup.
+	// Empty for query suggestions.
+	ID string `json:"id,omitempty"`
+	// The type of the result, either a location or a query suggestion.
+	ResultType ResultType `json:"resultType,omitempty"`
+	// Type of address data (returned only for address results). Is one of PA or interpolated.
+	HouseNumberType string `json:"houseNumberType,omitempty"`
+	// The result address in its related fields. Empty for query suggestions.
+	Address Address `json:"address,omitempty"`
+	// A representative geo-position (WGS 84) of the result. Nil for query suggestions.
+	Position *GeoWaypoint `json:"position,omitempty"`
+	// The geo-position of the access to the result (for instance the entrance).
+	Access []GeoWaypoint `json:"access,omitempty"`
+	// The distance in meters to the given spatial context ('at=lat,lon').
+	Distance int `json:"distance,omitempty"`
+	// Bounding box of the location optimized for display.
+	MapView *MapView `json:"mapView,omitempty"`
+	// The categories of a place result, or of a category query suggestion.
+	Categories []Category `json:"categories,omitempty"`
+	// The chains of a place result, or of a chain query suggestion.
+	Chains []Chain `json:"chains,omitempty"`
+	// The ranges of the title and address which match the query, e.g. to highlight them in a user interface.
+	Highlights Highlights `json:"highlighting,omitempty"`
+	// The URL of the follow-up search of a query suggestion. Empty for location results.
+	Href string `json:"href,omitempty"`
+}
+
+// IsQuery reports whether the item is a query suggestion, to be followed with its Href, rather than a location.
+func (i *AutosuggestItem) IsQuery() bool {
+	return i.ResultType.IsQuery()
+}
+
+type Category struct {
+	// The identifier of the category.
+	ID CategoryID `json:"id,omitempty"`
+	// The name of the category, in the language of the results.
+	Name string `json:"name,omitempty"`
+	// Whether the category is the primary category of the place.
+	Primary bool `json:"primary,omitempty"`
+}
+
+type Chain struct {
+	// The identifier of the chain.
+	ID string `json:"id,omitempty"`
+	// The name of the chain.
+	Name string `json:"name,omitempty"`
+}
+
+type Highlights struct {
+	// Ranges of the title matching the query.
+	Title []HighlightRange `json:"title,omitempty"`
+	// Ranges of the address fields matching the query.
+	Address AddressHighlights `json:"address,omitempty"`
+}
+
+type AddressHighlights struct {
+	Label       []HighlightRange `json:"label,omitempty"`
+	Country     []HighlightRange `json:"country,omitempty"`
+	CountryCode []HighlightRange `json:"countryCode,omitempty"`
+	State       []HighlightRange `json:"state,omitempty"`
+	County      []HighlightRange `json:"county,omitempty"`
+	City        []HighlightRange `json:"city,omitempty"`
+	District    []HighlightRange `json:"district,omitempty"`
+	Street      []HighlightRange `json:"street,omitempty"`
+	PostalCode  []HighlightRange `json:"postalCode,omitempty"`
+	HouseNumber []HighlightRange `json:"houseNumber,omitempty"`
+}
+
+// HighlightRange is a range of characters in a string, from the character at Start up to but excluding the
+// character at End.
+type HighlightRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Substring returns the characters of s in the range, or an empty string if the range is out of bounds.
+func (r HighlightRange) Substring(s string) string {
+	runes := []rune(s)
+	if r.Start < 0 || r.End > len(runes) || r.Start > r.End {
+		return ""
+	}
+	return string(runes[r.Start:r.End])
+}
+
+type QueryTerm struct {
+	// The term to suggest.
+	Term string `json:"term,omitempty"`
+	// The part of the query which the term replaces.
+	Replaces string `json:"replaces,omitempty"`
+	// The start of the replaced part, as a character index in the query.
+	Start int `json:"start"`
+	// The end of the replaced part, as an exclusive character index in the query.
+	End int `json:"end"`
+}
+
+type DiscoverResponse struct {
+	Items []PlaceItem `json:"items"`
+}
+
+type BrowseResponse struct {
+	Items []PlaceItem `json:"items"`
+}
+
+// PlaceItem is a result of the Discover and Browse place searches.
+type PlaceItem struct {
+	// A representative string for the result, for instance the name of a place, or a complete address.
+	Title string `json:"title,omitempty"`
+	// The identifier of the item. Its value can be used to retrieve the very same object with Lookup.
+	ID string `json:"id,omitempty"`
+	// The type of the result, usually ResultTypePlace.
+	ResultType ResultType `json:"resultType,omitempty"`
+	// The result address in its related fields.
+	Address Address `json:"address,omitempty"`
+	// A representative geo-position (WGS 84) of the result; this is to be used to display the result on a map.
+	Position GeoWaypoint `json:"position,omitempty"`
+	// The geo-position of the access to the result (for instance the entrance).
+	Access []GeoWaypoint `json:"access,omitempty"`
+	// The distance in meters to the given spatial context ('at=lat,lon').
+	Distance int `json:"distance,omitempty"`
+	// The categories of the place, with one primary category.
+	Categories []Category `json:"categories,omitempty"`
+	// The chains of the place, e.g. the brand of a fuel station.
+	Chains []Chain `json:"chains,omitempty"`
+	// The contact information of the place.
+	Contacts []Contact `json:"contacts,omitempty"`
+	// The opening hours of the place.
+	OpeningHours []OpeningHours `json:"openingHours,omitempty"`
+}
+
+// HasCategory reports whether the place has the category, or one of its subcategories.
+func (p *PlaceItem) HasCategory(category CategoryID) bool {
+	for _, c := range p.Categories {
+		if c.ID.IsWithin(category) {
+			return true
+		}
+	}
+	return false
+}
+
+type Contact struct {
+	Phone    []ContactInformation `json:"phone,omitempty"`
+	Mobile   []ContactInformation `json:"mobile,omitempty"`
+	TollFree []ContactInformation `json:"tollFree,omitempty"`
+	Fax      []ContactInformation `json:"fax,omitempty"`
+	WWW      []ContactInformation `json:"www,omitempty"`
+	Email    []ContactInformation `json:"email,omitempty"`
+}
+
+type ContactInformation struct {
+	// The phone number, URL or e-mail address.
+	Value string `json:"value"`
+	// A description of the contact, e.g. "Customer service".
+	Label string `json:"label,omitempty"`
+	// The categories of the place which the contact applies to. Empty if it applies to all of them.
+	Categories []CategoryReference `json:"categories,omitempty"`
+}
+
+type CategoryReference struct {
+	ID CategoryID `json:"id"`
+}
+
+type OpeningHours struct {
+	// The categories of the place which the opening hours apply to. Empty if they apply to all of them.
+	Categories []CategoryReference `json:"categories,omitempty"`
+	// The opening hours as text, e.g. "Mon-Fri: 06:00 - 22:00".
+	Text []string `json:"text,omitempty"`
+	// Whether the place is open at the time of the request.
+	IsOpen bool `json:"isOpen"`
+	// The opening hours as recurring periods.
+	Structured []StructuredOpeningHours `json:"structured,omitempty"`
+}
+
+// StructuredOpeningHours is a recurring period in which a place is open, in the iCalendar format of the HERE API.
+type StructuredOpeningHours struct {
+	// The local start time of the period, e.g. "T060000".
+	Start string `json:"start"`
+	// The duration of the period, e.g. "PT16H00M".
+	Duration string `json:"duration"`
+	// The recurrence of the period, e.g. "FREQ:DAILY;BYDAY:MO,TU,WE,TH,FR".
+	Recurrence string `json:"recurrence"`
+}
+
+// LookupResponse is the item with the requested identifier.
+type LookupResponse struct {
+	// A representative string for the result, for instance the name of a place, or a complete address.
+	Title string `json:"title,omitempty"`
+	// The identifier of the item.
+	ID string `json:"id,omitempty"`
+	// HERE Geocoding and Search supports multiple location object types (place, street, locality, ...).
+	ResultType ResultType `json:"resultType,omitempty"`
+	// Type of address data (returned only for address results). Is one of PA or interpolated.
+	HouseNumberType string `json:"houseNumberType,omitempty"`
+	// The result address in its related fields.
+	Address Address `json:"address,omitempty"`
+	// A representative geo-position (WGS 84) of the result; this is to be used to display the result on a map.
+	Position GeoWaypoint `json:"position,omitempty"`
+	// The geo-position of the access to the result (for instance the entrance).
+	Access []GeoWaypoint `json:"access,omitempty"`
+	// Bounding box of the location optimized for display.
+	MapView MapView `json:"mapView,omitempty"`
+	// The categories of a place.
+	Categories []Category `json:"categories,omitempty"`
+	// The chains of a place.
+	Chains []Chain `json:"chains,omitempty"`
+	// The contact information of a place.
+	Contacts []Contact `json:"contacts,omitempty"`
+	// The opening hours of a place.
+	OpeningHours []OpeningHours `json:"openingHours,omitempty"`
+	// The time zone of the result. Only set with ShowTimeZone.
+	TimeZone *TimeZone `json:"timeZone,omitempty"`
+	// The parts of the street names of the result. Only set with ShowStreetInfo.
+	StreetInfo []StreetInfo `json:"streetInfo,omitempty"`
+	// The ISO 3166-1 codes of the country of the result. Only set with ShowCountryInfo.
+	CountryInfo *CountryInfo `json:"countryInfo,omitempty"`
+}
+
+type TimeZone struct {
+	// The name of the time zone in the IANA time zone database, e.g. "Europe/Stockholm".
+	Name string `json:"name"`
+	// The UTC offset of the time zone at the time of the request, e.g. "+02:00".
+	UTCOffset string `json:"utcOffset"`
+}
+
+// Location returns the time zone as a location of the time package, to convert times to the local time of a result.
+func (t *TimeZone) Location() (*time.Location, error) {
+	return time.LoadLocation(t.Name)
+}
+
+type StreetInfo struct {
+	// The base name of the street, e.g. "Regerings" for "Regeringsgatan".
+	BaseName string `json:"baseName,omitempty"`
+	// The type of the street, e.g. "gatan".
+	StreetType string `json:"streetType,omitempty"`
+	// Whether the street type precedes the base name, as in "Rue de Rivoli".
+	StreetTypePrecedes bool `json:"streetTypePrecedes,omitempty"`
+	// Whether the street type is attached to the base name, as in "Regeringsgatan".
+	StreetTypeAttached bool `json:"streetTypeAttached,omitempty"`
+	// The prefix of the street name, e.g. "N" in "N Main St".
+	Prefix string `json:"prefix,omitempty"`
+	// The suffix of the street name, e.g. "NW" in "Main St NW".
+	Suffix string `json:"suffix,omitempty"`
+	// The direction of the street, e.g. "N".
+	Direction string `json:"direction,omitempty"`
+	// The BCP 47 language code of the street name.
+	Language string `json:"language,omitempty"`
+}
+
+type CountryInfo struct {
+	// The ISO 3166-1 alpha-2 country code, e.g. "SE".
+	Alpha2 string `json:"alpha2"`
+	// The ISO 3166-1 alpha-3 country code, e.g. "SWE".
+	Alpha3 string `json:"alpha3"`
+}
+
+// Parsing holds the parts of a query which matched each field of a result.
+type Parsing struct {
+	PlaceName   []ParsingMatch `json:"placeName,omitempty"`
+	Country     []ParsingMatch `json:"country,omitempty"`
+	State       []ParsingMatch `json:"state,omitempty"`
+	County      []ParsingMatch `json:"county,omitempty"`
+	City        []ParsingMatch `json:"city,omitempty"`
+	District    []ParsingMatch `json:"district,omitempty"`
+	Subdistrict []ParsingMatch `json:"subdistrict,omitempty"`
+	Street      []ParsingMatch `json:"street,omitempty"`
+	Block       []ParsingMatch `json:"block,omitempty"`
+	Subblock    []ParsingMatch `json:"subblock,omitempty"`
+	HouseNumber []ParsingMatch `json:"houseNumber,omitempty"`
+	PostalCode  []ParsingMatch `json:"postalCode,omitempty"`
+	Building    []ParsingMatch `json:"building,omitempty"`
+}
+
+// ParsingMatch is a part of a query which matched a field of a result.
+type ParsingMatch struct {
+	// The start of the part, as a character index in the query.
+	Start int `json:"start"`
+	// The end of the part, as an exclusive character index in the query.
+	End int `json:"end"`
+	// The matched part of the query.
+	Value string `json:"value"`
+	// The field of the qualified query which the part was given in, e.g. "city". Empty for free text queries.
+	QQ string `json:"qq,omitempty"`
+}
@@ -13,27 +13,29 @@ import (
 func (s *ReverseGeocodingService) ReverseGeocoding(
 	ctx context.Context,
 	req *ReverseGeocodingRequest,
-) (*ReverseGeocodingResponse, error) {
+) (_ *ReverseGeocodingResponse, err error) {
+	ctx, op := s.Client.startOperation(ctx, "ReverseGeocoding")
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("revgeocode")
 	if err != nil {
 		return nil, err
 	}
 
-	if req.GeoPosition == nil {
-		return nil, fmt.Errorf("InvalidArgument, GeoPosition must be provided")
+	if req.GeoPosition == nil && !req.Area.hasShape() {
+		return nil, fmt.Errorf("InvalidArgument, %w", errMissingPosition)
 	}
 
 	values := make(url.Values)
-	if req.GeoPosition != nil {
-		values.Add("at", fmt.Sprintf("%v,%v", req.GeoPosition.Lat, req.GeoPosition.Long))
+	if err := addSearchParameters(values, req.GeoPosition, req.Area, 0, nil); err != nil {
+		return nil, err
 	}
-	if req.In != nil {
-		values.Add("in", *req.In)
+	if err := addDeprecatedIn(values, req.In, req.Area); err != nil {
+		return nil, err
 	}
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp ReverseGeocodingResponse
 	if err := s.Client.Do(r, &resp); err != nil {
@@ -0,0 +1,183 @@
+package geocodingsearchv7
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SearchArea is a geographic area to search within, a hard filter on the results of the search endpoints. It is
+// a circle or a bounding box, optionally restricted to countries, or only countries.
+type SearchArea struct {
+	// Countries to search within, as ISO 3166-1 alpha-3 country codes, e.g. "SWE".
+	Countries []string
+	// Circle to search within. Mutually exclusive with BoundingBox.
+	Circle *Circle
+	// BoundingBox to search within. Mutually exclusive with Circle.
+	BoundingBox *BoundingBox
+}
+
+type Circle struct {
+	// The center of the circle.
+	Center GeoWaypoint
+	// The radius of the circle, in meters.
+	RadiusMeters int
+}
+
+type BoundingBox struct {
+	// The west longitude of the box.
+	West float64
+	// The south latitude of the box.
+	South float64
+	// The east longitude of the box.
+	East float64
+	// The north latitude of the box.
+	North float64
+}
+
+// InCountries returns a search area of the countries, given as ISO 3166-1 alpha-3 country codes.
+func InCountries(countries ...string) *SearchArea {
+	return &SearchArea{Countries: countries}
+}
+
+// InCircle returns a search area of the circle with the center and radius.
+func InCircle(center GeoWaypoint, radiusMeters int) *SearchArea {
+	return &SearchArea{Circle: &Circle{Center: center, RadiusMeters: radiusMeters}}
+}
+
+// InBoundingBox returns a search area of the bounding box.
+func InBoundingBox(west, south, east, north float64) *SearchArea {
+	return &SearchArea{BoundingBox: &BoundingBox{West: west, South: south, East: east, North: north}}
+}
+
+// Validate checks that the search area has a valid shape or countries.
+func (a *SearchArea) Validate() error {
+	if a.Circle == nil && a.BoundingBox == nil && len(a.Countries) == 0 {
+		return fmt.Errorf("invalid search area: empty")
+	}
+	if a.Circle != nil && a.BoundingBox != nil {
+		return fmt.Errorf("invalid search area: both circle and bounding box")
+	}
+	for _, country := range a.Countries {
+		if !isCountryCode(country) {
+			return fmt.Errorf("invalid search area: invalid country code %q", country)
+		}
+	}
+	if c := a.Circle; c != nil {
+		if !isLatitude(c.Center.Lat) || !isLongitude(c.Center.Long) {
+			return fmt.Errorf("invalid search area: invalid circle center %v,%v", c.Center.Lat, c.Center.Long)
+		}
+		if c.RadiusMeters <= 0 {
+			return fmt.Errorf("invalid search area: non-positive circle radius %d", c.RadiusMeters)
+		}
+	}
+	if b := a.BoundingBox; b != nil {
+		if !isLongitude(b.West) || !isLongitude(b.East) || !isLatitude(b.South) || !isLatitude(b.North) {
+			return fmt.Errorf("invalid search area: bounding box out of range")
+		}
+		if b.West >= b.East || b.South >= b.North {
+			return fmt.Errorf("invalid search area: empty bounding box")
+		}
+	}
+	return nil
+}
+
+// hasShape reports whether the search area has a circle or bounding box, which can replace the position of a search.
+func (a *SearchArea) hasShape() bool {
+	return a != nil && (a.Circle != nil || a.BoundingBox != nil)
+}
+
+// String returns the search area in the format of the in parameter of the HERE API, e.g.
+// "circle:59.33749,18.06367;r=5000;countryCode:SWE,NOR".
+func (a *SearchArea) String() string {
+	var parts []string
+	if c := a.Circle; c != nil {
+		parts = append(parts, fmt.Sprintf("circle:%v,%v;r=%d", c.Center.Lat, c.Center.Long, c.RadiusMeters))
+	}
+	if b := a.BoundingBox; b != nil {
+		parts = append(parts, fmt.Sprintf("bbox:%v,%v,%v,%v", b.West, b.South, b.East, b.North))
+	}
+	if len(a.Countries) > 0 {
+		parts = append(parts, "countryCode:"+strings.Join(a.Countries, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseSearchArea parses a search area in the format of the in parameter of the HERE API, as returned by
+// SearchArea.String, and validates it.
+func ParseSearchArea(s string) (*SearchArea, error) {
+	var area SearchArea
+	parts := strings.Split(s, ";")
+	for i := 0; i < len(parts); i++ {
+		kind, value, ok := strings.Cut(parts[i], ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid search area %q", s)
+		}
+		switch kind {
+		case "countryCode":
+			area.Countries = append(area.Countries, strings.Split(value, ",")...)
+		case "circle":
+			if i+1 == len(parts) || !strings.HasPrefix(parts[i+1], "r=") {
+				return nil, fmt.Errorf("invalid search area %q: missing circle radius", s)
+			}
+			i++
+			center, err := parseFloats(value, 2)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search area %q: %w", s, err)
+			}
+			radius, err := strconv.Atoi(strings.TrimPrefix(parts[i], "r="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid search area %q: %w", s, err)
+			}
+			area.Circle = &Circle{Center: GeoWaypoint{Lat: center[0], Long: center[1]}, RadiusMeters: radius}
+		case "bbox":
+			box, err := parseFloats(value, 4)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search area %q: %w", s, err)
+			}
+			area.BoundingBox = &BoundingBox{West: box[0], South: box[1], East: box[2], North: box[3]}
+		default:
+			return nil, fmt.Errorf("invalid search area %q: unknown filter %q", s, kind)
+		}
+	}
+	if err := area.Validate(); err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+func parseFloats(s string, n int) ([]float64, error) {
+	values := strings.Split(s, ",")
+	if len(values) != n {
+		return nil, fmt.Errorf("expected %d coordinates, got %d", n, len(values))
+	}
+	result := make([]float64, 0, n)
+	for _, value := range values {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func isCountryCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func isLongitude(lng float64) bool {
+	return lng >= -180 && lng <= 180
+}
@@ -0,0 +1,224 @@
+package geocodingsearchv7_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"gotest.tools/v3/assert"
+)
+
+func TestSearchArea_String(t *testing.T) {
+	t.Parallel()
+	stockholm := geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	for _, tt := range []struct {
+		name     string
+		area     *geocodingsearchv7.SearchArea
+		expected string
+	}{
+		{name: "country", area: geocodingsearchv7.InCountries("SWE"), expected: "countryCode:SWE"},
+		{name: "countries", area: geocodingsearchv7.InCountries("SWE", "NOR"), expected: "countryCode:SWE,NOR"},
+		{
+			name:     "circle",
+			area:     geocodingsearchv7.InCircle(stockholm, 5000),
+			expected: "circle:59.33749,18.06367;r=5000",
+		},
+		{
+			name:     "bounding box",
+			area:     geocodingsearchv7.InBoundingBox(17.9, 59.2, 18.2, 59.4),
+			expected: "bbox:17.9,59.2,18.2,59.4",
+		},
+		{
+			name: "circle in countries",
+			area: &geocodingsearchv7.SearchArea{
+				Countries: []string{"SWE", "NOR"},
+				Circle:    &geocodingsearchv7.Circle{Center: stockholm, RadiusMeters: 5000},
+			},
+			expected: "circle:59.33749,18.06367;r=5000;countryCode:SWE,NOR",
+		},
+		{
+			name: "bounding box in country",
+			area: &geocodingsearchv7.SearchArea{
+				Countries:   []string{"SWE"},
+				BoundingBox: &geocodingsearchv7.BoundingBox{West: 17.9, South: 59.2, East: 18.2, North: 59.4},
+			},
+			expected: "bbox:17.9,59.2,18.2,59.4;countryCode:SWE",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NilError(t, tt.area.Validate())
+			assert.Equal(t, tt.area.String(), tt.expected)
+			parsed, err := geocodingsearchv7.ParseSearchArea(tt.expected)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, parsed, tt.area)
+		})
+	}
+}
+
+func TestSearchArea_Validate(t *testing.T) {
+	t.Parallel()
+	stockholm := geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	for _, tt := range []struct {
+		name   string
+		area   *geocodingsearchv7.SearchArea
+		errStr string
+	}{
+		{name: "empty", area: &geocodingsearchv7.SearchArea{}, errStr: "invalid search area: empty"},
+		{
+			name: "circle and bounding box",
+			area: &geocodingsearchv7.SearchArea{
+				Circle:      &geocodingsearchv7.Circle{Center: stockholm, RadiusMeters: 5000},
+				BoundingBox: &geocodingsearchv7.BoundingBox{West: 17.9, South: 59.2, East: 18.2, North: 59.4},
+			},
+			errStr: "invalid search area: both circle and bounding box",
+		},
+		{
+			name:   "alpha-2 country code",
+			area:   geocodingsearchv7.InCountries("SE"),
+			errStr: `invalid search area: invalid country code "SE"`,
+		},
+		{
+			name:   "lowercase country code",
+			area:   geocodingsearchv7.InCountries("swe"),
+			errStr: `invalid search area: invalid country code "swe"`,
+		},
+		{
+			name:   "circle center out of range",
+			area:   geocodingsearchv7.InCircle(geocodingsearchv7.GeoWaypoint{Lat: 91, Long: 18}, 5000),
+			errStr: "invalid search area: invalid circle center 91,18",
+		},
+		{
+			name:   "zero radius",
+			area:   geocodingsearchv7.InCircle(stockholm, 0),
+			errStr: "invalid search area: non-positive circle radius 0",
+		},
+		{
+			name:   "bounding box out of range",
+			area:   geocodingsearchv7.InBoundingBox(17.9, 59.2, 181, 59.4),
+			errStr: "invalid search area: bounding box out of range",
+		},
+		{
+			name:   "inverted bounding box",
+			area:   geocodingsearchv7.InBoundingBox(17.9, 59.4, 18.2, 59.2),
+			errStr: "invalid search area: empty bounding box",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Error(t, tt.area.Validate(), tt.errStr)
+		})
+	}
+}
+
+func TestParseSearchArea_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		s      string
+		errStr string
+	}{
+		{s: "", errStr: `invalid search area ""`},
+		{s: "SWE", errStr: `invalid search area "SWE"`},
+		{s: "country:SWE", errStr: `invalid search area "country:SWE": unknown filter "country"`},
+		{s: "circle:59.3,18.1", errStr: `invalid search area "circle:59.3,18.1": missing circle radius`},
+		{s: "circle:59.3;r=5000", errStr: `invalid search area "circle:59.3;r=5000": expected 2 coordinates, got 1`},
+		{s: "countryCode:SWE,se", errStr: `invalid search area: invalid country code "se"`},
+	} {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			_, err := geocodingsearchv7.ParseSearchArea(tt.s)
+			assert.Error(t, err, tt.errStr)
+		})
+	}
+}
+
+func TestSearchArea_Requests(t *testing.T) {
+	t.Parallel()
+	stockholm := &geocodingsearchv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	t.Run("serialized", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.ReverseGeocoding.ReverseGeocoding(context.Background(), &geocodingsearchv7.ReverseGeocodingRequest{
+			GeoPosition: stockholm,
+			Area:        geocodingsearchv7.InCountries("SWE", "NOR"),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, mock.requests[0].URL.Query().Get("in"), "countryCode:SWE,NOR")
+	})
+	t.Run("reverse geocoding in circle", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.ReverseGeocoding.ReverseGeocoding(context.Background(), &geocodingsearchv7.ReverseGeocodingRequest{
+			Area: geocodingsearchv7.InCircle(*stockholm, 500),
+		})
+		assert.NilError(t, err)
+		query := mock.requests[0].URL.Query()
+		assert.Equal(t, query.Get("in"), "circle:59.33749,18.06367;r=500")
+		assert.Equal(t, query.Get("at"), "")
+	})
+	t.Run("reverse geocoding in countries", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.ReverseGeocoding.ReverseGeocoding(context.Background(), &geocodingsearchv7.ReverseGeocodingRequest{
+			Area: geocodingsearchv7.InCountries("SWE"),
+		})
+		assert.Error(t, err, "InvalidArgument, either GeoPosition or Area with a circle or bounding box must be provided")
+		assert.Equal(t, len(mock.requests), 0)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		q := "Stockholm"
+		_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{
+			Q:    &q,
+			Area: geocodingsearchv7.InCountries("SE"),
+		})
+		assert.Error(t, err, `InvalidArgument, invalid search area: invalid country code "SE"`)
+		assert.Equal(t, len(mock.requests), 0)
+	})
+	t.Run("deprecated in", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		q, in := "Stockholm", "countryCode:SWE"
+		_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{
+			Q:  &q,
+			In: &in,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, mock.requests[0].URL.Query().Get("in"), "countryCode:SWE")
+	})
+	t.Run("deprecated in with area", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		in := "countryCode:SWE"
+		_, err := client.ReverseGeocoding.ReverseGeocoding(context.Background(), &geocodingsearchv7.ReverseGeocodingRequest{
+			GeoPosition: stockholm,
+			Area:        geocodingsearchv7.InCountries("SWE"),
+			In:          &in,
+		})
+		assert.Error(t, err, "InvalidArgument, In and Area are mutually exclusive")
+		assert.Equal(t, len(mock.requests), 0)
+	})
+	t.Run("shape with position", func(t *testing.T) {
+		t.Parallel()
+		mock := RawResponseMock{statusCode: http.StatusOK, body: `{"items": []}`}
+		client := geocodingsearchv7.NewClient(&mock)
+		_, err := client.Discover.Discover(context.Background(), &geocodingsearchv7.DiscoverRequest{
+			Q:           "truck stop",
+			GeoPosition: stockholm,
+			Area:        geocodingsearchv7.InCircle(*stockholm, 5000),
+		})
+		assert.Error(t, err, "InvalidArgument, GeoPosition must not be combined with a circle or bounding box")
+		assert.Equal(t, len(mock.requests), 0)
+	})
+}
@@ -0,0 +1,94 @@
+package heretest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault describes a failure to inject into the responses of a Server.
+type Fault struct {
+	// Path prefix of the requests to inject the fault into, e.g. /v8/matrix. Every request if empty.
+	Path string
+	// Count is the number of requests to inject the fault into. Every matching request if zero.
+	Count int
+	// Latency to add before responding. Responses are abandoned if the request is cancelled meanwhile.
+	Latency time.Duration
+	// StatusCode to respond with instead of the regular response, e.g. 429 or 503, with a HERE error body.
+	StatusCode int
+	// RetryAfter is sent as the Retry-After header of the StatusCode response, in whole seconds. Not sent if zero.
+	RetryAfter time.Duration
+	// Malformed truncates the body of the regular response, so that it can not be decoded.
+	Malformed bool
+}
+
+type fault struct {
+	Fault
+	remaining int
+}
+
+// InjectFault injects a fault into the responses of the server. When several faults match a request, the first
+// injected one is applied.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f, remaining: f.Count})
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// nextFault returns the fault to apply to a request, if any. Must be called with mu held.
+func (s *Server) nextFault(r *http.Request) *fault {
+	for i, f := range s.faults {
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Count > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apply applies the fault to a request, and reports whether the regular response should still be written.
+func (f *fault) apply(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) bool {
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+	switch {
+	case f.StatusCode != 0:
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+		}
+		writeError(w, r, f.StatusCode, "Injected fault")
+		return false
+	case f.Malformed:
+		recorder := httptest.NewRecorder()
+		next(recorder, r)
+		for key, values := range recorder.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(recorder.Code)
+		body := recorder.Body.Bytes()
+		_, _ = w.Write(body[:len(body)/2])
+		return false
+	}
+	return true
+}
@@ -0,0 +1,615 @@
+package heretest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.einride.tech/here/geocodingsearchv7"
+)
+
+func (s *Server) geocode(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	q := query.Get("q")
+	if q == "" {
+		q = query.Get("qq")
+	}
+	if q == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing q or qq")
+		return
+	}
+	var response struct {
+		Items []geocodingsearchv7.GeocodingItem `json:"items"`
+	}
+	response.Items = []geocodingsearchv7.GeocodingItem{}
+	if place, ok := s.geocodePlace(q, parseCountries(query.Get("in"))); ok {
+		response.Items = append(response.Items, geocodingsearchv7.GeocodingItem{
+			Title:      place.Title,
+			ID:         place.ID,
+			ResultType: place.ResultType,
+			Address:    place.Address,
+			Position:   place.Position,
+			Access:     []geocodingsearchv7.GeoWaypoint{place.Position},
+			MapView:    mapView(place.Position),
+			Scoring:    geocodingsearchv7.Scoring{QueryScore: 1},
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) reverseGeocode(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	// A circle or bounding box replaces at, with its center as the position.
+	at, ok := parseShapeCenter(query.Get("in"))
+	if query.Get("at") != "" || !ok {
+		var err error
+		if at, err = parsePoint(query.Get("at")); err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid at: "+err.Error())
+			return
+		}
+	}
+	place := s.reverseGeocodePlace(at, parseCountries(query.Get("in")))
+	writeJSON(w, http.StatusOK, struct {
+		Items []geocodingsearchv7.ReverseGeocodingItem `json:"items"`
+	}{
+		Items: []geocodingsearchv7.ReverseGeocodingItem{{
+			Title:      place.Title,
+			ID:         place.ID,
+			ResultType: place.ResultType,
+			Address:    place.Address,
+			Position:   place.Position,
+			Access:     []geocodingsearchv7.GeoWaypoint{place.Position},
+			MapView:    mapView(place.Position),
+			Distance:   int(distance(at, point{lat: place.Position.Lat, lng: place.Position.Long})),
+		}},
+	})
+}
+
+func (s *Server) autosuggest(w http.ResponseWriter, r *http.Request) {
+	params, ok := parseSearchParameters(w, r, true)
+	if !ok {
+		return
+	}
+	response := geocodingsearchv7.AutosuggestResponse{Items: []geocodingsearchv7.AutosuggestItem{}}
+	places := s.searchPlaces(params, func(place Place) bool {
+		return highlight(place.Title, params.q) != nil || highlight(place.Address.Label, params.q) != nil
+	})
+	for _, place := range places {
+		position := place.Position
+		item := geocodingsearchv7.AutosuggestItem{
+			Title:      place.Title,
+			ID:         place.ID,
+			ResultType: geocodingsearchv7.ResultType(place.ResultType),
+			Address:    place.Address,
+			Position:   &position,
+			Access:     []geocodingsearchv7.GeoWaypoint{place.Position},
+			Categories: place.Categories,
+			Highlights: geocodingsearchv7.Highlights{
+				Title:   highlight(place.Title, params.q),
+				Address: geocodingsearchv7.AddressHighlights{Label: highlight(place.Address.Label, params.q)},
+			},
+		}
+		if params.at != nil {
+			item.Distance = int(distance(*params.at, point{lat: place.Position.Lat, lng: place.Position.Long}))
+		}
+		response.Items = append(response.Items, item)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) discover(w http.ResponseWriter, r *http.Request) {
+	params, ok := parseSearchParameters(w, r, true)
+	if !ok {
+		return
+	}
+	places := s.searchPlaces(params, func(place Place) bool {
+		if highlight(place.Title, params.q) != nil || highlight(place.Address.Label, params.q) != nil {
+			return true
+		}
+		for _, category := range place.Categories {
+			if highlight(category.Name, params.q) != nil {
+				return true
+			}
+		}
+		return false
+	})
+	writeJSON(w, http.StatusOK, geocodingsearchv7.DiscoverResponse{Items: placeItems(places, params.at)})
+}
+
+func (s *Server) browse(w http.ResponseWriter, r *http.Request) {
+	params, ok := parseSearchParameters(w, r, false)
+	if !ok {
+		return
+	}
+	if params.at == nil {
+		writeError(w, r, http.StatusBadRequest, "Missing at")
+		return
+	}
+	query := r.URL.Query()
+	var categories []geocodingsearchv7.CategoryID
+	if query.Get("categories") != "" {
+		for _, category := range strings.Split(query.Get("categories"), ",") {
+			categories = append(categories, geocodingsearchv7.CategoryID(category))
+		}
+	}
+	name := query.Get("name")
+	places := s.searchPlaces(params, func(place Place) bool {
+		if name != "" && highlight(place.Title, name) == nil {
+			return false
+		}
+		if len(categories) == 0 {
+			return true
+		}
+		item := geocodingsearchv7.PlaceItem{Categories: place.Categories}
+		for _, category := range categories {
+			if item.HasCategory(category) {
+				return true
+			}
+		}
+		return false
+	})
+	writeJSON(w, http.StatusOK, geocodingsearchv7.BrowseResponse{Items: placeItems(places, params.at)})
+}
+
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing id")
+		return
+	}
+	for _, place := range s.config.Places {
+		place = withDefaults(place)
+		if place.ID != id {
+			continue
+		}
+		writeJSON(w, http.StatusOK, geocodingsearchv7.LookupResponse{
+			Title:      place.Title,
+			ID:         place.ID,
+			ResultType: geocodingsearchv7.ResultType(place.ResultType),
+			Address:    place.Address,
+			Position:   place.Position,
+			Access:     []geocodingsearchv7.GeoWaypoint{place.Position},
+			MapView:    mapView(place.Position),
+			Categories: place.Categories,
+		})
+		return
+	}
+	writeError(w, r, http.StatusNotFound, "Id '"+id+"' not found")
+}
+
+// searchParameters are the parameters shared by the search endpoints.
+type searchParameters struct {
+	q         string
+	at        *point
+	countries []string
+	limit     int
+}
+
+// parseSearchParameters parses the parameters shared by the search endpoints, and writes an error response if
+// they are invalid. Either at or in with a circle or bounding box is required, and q if requireQuery is set.
+func parseSearchParameters(w http.ResponseWriter, r *http.Request, requireQuery bool) (searchParameters, bool) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return searchParameters{}, false
+	}
+	query := r.URL.Query()
+	params := searchParameters{q: query.Get("q"), countries: parseCountries(query.Get("in")), limit: 20}
+	if requireQuery && params.q == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing q")
+		return searchParameters{}, false
+	}
+	if query.Get("at") != "" {
+		p, err := parsePoint(query.Get("at"))
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid at: "+err.Error())
+			return searchParameters{}, false
+		}
+		params.at = &p
+	} else if _, ok := parseShapeCenter(query.Get("in")); !ok {
+		writeError(w, r, http.StatusBadRequest, "Missing at, or in with a circle or bounding box")
+		return searchParameters{}, false
+	}
+	if query.Get("limit") != "" {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit < 1 {
+			writeError(w, r, http.StatusBadRequest, "Invalid limit")
+			return searchParameters{}, false
+		}
+		params.limit = limit
+	}
+	return params, true
+}
+
+// searchPlaces returns up to the limit of the parameters of the known places within their countries which match,
+// sorted by distance from their position if any.
+func (s *Server) searchPlaces(params searchParameters, match func(Place) bool) []Place {
+	var places []Place
+	for _, place := range s.config.Places {
+		place = withDefaults(place)
+		if inCountries(place, params.countries) && match(place) {
+			places = append(places, place)
+		}
+	}
+	if params.at != nil {
+		sort.SliceStable(places, func(i, j int) bool {
+			return distance(*params.at, point{lat: places[i].Position.Lat, lng: places[i].Position.Long}) <
+				distance(*params.at, point{lat: places[j].Position.Lat, lng: places[j].Position.Long})
+		})
+	}
+	if len(places) > params.limit {
+		places = places[:params.limit]
+	}
+	return places
+}
+
+func placeItems(places []Place, at *point) []geocodingsearchv7.PlaceItem {
+	items := make([]geocodingsearchv7.PlaceItem, 0, len(places))
+	for _, place := range places {
+		item := geocodingsearchv7.PlaceItem{
+			Title:      place.Title,
+			ID:         place.ID,
+			ResultType: geocodingsearchv7.ResultType(place.ResultType),
+			Address:    place.Address,
+			Position:   place.Position,
+			Access:     []geocodingsearchv7.GeoWaypoint{place.Position},
+			Categories: place.Categories,
+		}
+		if at != nil {
+			item.Distance = int(distance(*at, point{lat: place.Position.Lat, lng: place.Position.Long}))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// highlight returns the range of the first case-insensitive occurrence of q in s, in characters, or nil if s does
+// not contain q.
+func highlight(s, q string) []geocodingsearchv7.HighlightRange {
+	runes, queryRunes := []rune(strings.ToLower(s)), []rune(strings.ToLower(q))
+	for i := 0; i+len(queryRunes) <= len(runes); i++ {
+		if string(runes[i:i+len(queryRunes)]) == string(queryRunes) {
+			return []geocodingsearchv7.HighlightRange{{Start: i, End: i + len(queryRunes)}}
+		}
+	}
+	return nil
+}
+
+// geocodePlace returns the known place matching the query, or a place derived from the query if no known place
+// matches. No place is returned if it is outside the countries.
+func (s *Server) geocodePlace(q string, countries []string) (Place, bool) {
+	for _, place := range s.config.Places {
+		if strings.EqualFold(place.Title, q) || strings.EqualFold(place.Address.Label, q) {
+			place = withDefaults(place)
+			return place, inCountries(place, countries)
+		}
+	}
+	p := hashPoint(q)
+	place := Place{
+		Title:    q,
+		Position: geocodingsearchv7.GeoWaypoint{Lat: p.lat, Long: p.lng},
+		Address:  geocodingsearchv7.Address{Label: q},
+	}
+	if len(countries) > 0 {
+		place.Address.CountryCode = countries[0]
+	}
+	return withDefaults(place), true
+}
+
+// reverseGeocodePlace returns the known place closest to the point, or a place at the point if there are no known
+// places within the countries.
+func (s *Server) reverseGeocodePlace(at point, countries []string) Place {
+	var closest *Place
+	closestDistance := math.Inf(1)
+	for i, place := range s.config.Places {
+		if !inCountries(place, countries) {
+			continue
+		}
+		if d := distance(at, point{lat: place.Position.Lat, lng: place.Position.Long}); d < closestDistance {
+			closest, closestDistance = &s.config.Places[i], d
+		}
+	}
+	if closest != nil {
+		return withDefaults(*closest)
+	}
+	title := fmt.Sprintf("%.5f,%.5f", at.lat, at.lng)
+	place := Place{
+		Title:    title,
+		Position: geocodingsearchv7.GeoWaypoint{Lat: at.lat, Long: at.lng},
+		Address:  geocodingsearchv7.Address{Label: title},
+	}
+	if len(countries) > 0 {
+		place.Address.CountryCode = countries[0]
+	}
+	return withDefaults(place)
+}
+
+func withDefaults(place Place) Place {
+	if place.ID == "" {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(place.Title))
+		place.ID = fmt.Sprintf("here:heretest:%016x", h.Sum64())
+	}
+	if place.ResultType == "" {
+		place.ResultType = "houseNumber"
+	}
+	if place.Address.Label == "" {
+		place.Address.Label = place.Title
+	}
+	return place
+}
+
+// parseCountries returns the countries of the in parameter, which may be combined with a circle or bounding box,
+// e.g. "circle:59.33,18.06;r=5000;countryCode:SWE,NOR". Circles and bounding boxes are accepted and ignored.
+func parseCountries(in string) []string {
+	for _, part := range strings.Split(in, ";") {
+		if codes, ok := strings.CutPrefix(part, "countryCode:"); ok && codes != "" {
+			return strings.Split(codes, ",")
+		}
+	}
+	return nil
+}
+
+// parseShapeCenter returns the center of the circle or bounding box of the in parameter, e.g.
+// "circle:59.33,18.06;r=5000", and false if it has none.
+func parseShapeCenter(in string) (point, bool) {
+	if circle, ok := strings.CutPrefix(in, "circle:"); ok {
+		center, err := parsePoint(circle)
+		return center, err == nil
+	}
+	if bbox, ok := strings.CutPrefix(in, "bbox:"); ok {
+		bbox, _, _ = strings.Cut(bbox, ";")
+		var coordinates []float64
+		for _, s := range strings.Split(bbox, ",") {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return point{}, false
+			}
+			coordinates = append(coordinates, f)
+		}
+		if len(coordinates) != 4 {
+			return point{}, false
+		}
+		return point{lat: (coordinates[1] + coordinates[3]) / 2, lng: (coordinates[0] + coordinates[2]) / 2}, true
+	}
+	return point{}, false
+}
+
+func inCountries(place Place, countries []string) bool {
+	if len(countries) == 0 {
+		return true
+	}
+	for _, country := range countries {
+		if strings.EqualFold(place.Address.CountryCode, country) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapView(p geocodingsearchv7.GeoWaypoint) geocodingsearchv7.MapView {
+	const margin = 0.001
+	return geocodingsearchv7.MapView{
+		West:  p.Long - margin,
+		South: p.Lat - margin,
+		East:  p.Long + margin,
+		North: p.Lat + margin,
+	}
+}
+
+// batchResponse is the response body of the batch geocoder.
+type batchResponse struct {
+	XMLName xml.Name `xml:"SearchResponse"`
+	geocodingsearchv7.BatchGeocoderResponse
+}
+
+// batchJob is a batch geocoder job.
+type batchJob struct {
+	pendingPolls int
+	outDelim     string
+	outCols      []string
+	rows         [][]string
+	invalid      int
+}
+
+const defaultBatchOutCols = "displayLatitude,displayLongitude,locationLabel"
+
+func (s *Server) batchUpload(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	query := r.URL.Query()
+	if action := query.Get("action"); action != "run" {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid action %q", action))
+		return
+	}
+	inDelim, outDelim, outCols := query.Get("indelim"), query.Get("outdelim"), query.Get("outcols")
+	if inDelim == "" {
+		inDelim = "|"
+	}
+	if outDelim == "" {
+		outDelim = "|"
+	}
+	if outCols == "" {
+		outCols = defaultBatchOutCols
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid body: "+err.Error())
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n")), "\n")
+	if len(lines) < 2 {
+		writeError(w, r, http.StatusBadRequest, "Body must contain a header and at least one record")
+		return
+	}
+	job := &batchJob{
+		pendingPolls: s.config.PendingPolls,
+		outDelim:     outDelim,
+		outCols:      append([]string{"recId", "SeqNumber", "seqLength"}, strings.Split(outCols, ",")...),
+	}
+	header := strings.Split(lines[0], inDelim)
+	for _, line := range lines[1:] {
+		fields := make(map[string]string, len(header))
+		for i, value := range strings.Split(line, inDelim) {
+			if i < len(header) {
+				fields[header[i]] = value
+			}
+		}
+		place, ok := s.batchPlace(fields, query.Get("mode") == "retrieveAddresses")
+		if !ok {
+			job.invalid++
+			continue
+		}
+		job.rows = append(job.rows, job.row(fields["recId"], place))
+	}
+	s.mu.Lock()
+	id := s.nextID("job")
+	s.jobs[id] = job
+	s.mu.Unlock()
+	var response batchResponse
+	response.Response.MetaInfo.RequestID = id
+	response.Response.Status = geocodingsearchv7.JobStatusAccepted
+	response.Response.TotalCount = len(lines) - 1
+	writeXML(w, http.StatusOK, response)
+}
+
+// batchPlace returns the place of a record of a batch geocoder job, and false if the record is invalid.
+func (s *Server) batchPlace(fields map[string]string, reverse bool) (Place, bool) {
+	if reverse {
+		at, err := parsePoint(fields["prox"])
+		if err != nil {
+			return Place{}, false
+		}
+		return s.reverseGeocodePlace(at, nil), true
+	}
+	q := fields["searchText"]
+	if q == "" {
+		var parts []string
+		for _, key := range []string{"street", "houseNumber", "postalCode", "district", "city", "county", "state"} {
+			if fields[key] != "" {
+				parts = append(parts, fields[key])
+			}
+		}
+		q = strings.Join(parts, " ")
+	}
+	if q == "" {
+		return Place{}, false
+	}
+	var countries []string
+	if fields["country"] != "" {
+		countries = []string{fields["country"]}
+	}
+	return s.geocodePlace(q, countries)
+}
+
+// row returns the output columns of a place.
+func (j *batchJob) row(recID string, place Place) []string {
+	values := map[string]string{
+		"recId":            recID,
+		"SeqNumber":        "1",
+		"seqLength":        "1",
+		"displayLatitude":  strconv.FormatFloat(place.Position.Lat, 'f', -1, 64),
+		"displayLongitude": strconv.FormatFloat(place.Position.Long, 'f', -1, 64),
+		"locationLabel":    place.Address.Label,
+		"houseNumber":      place.Address.HouseNumber,
+		"street":           place.Address.Street,
+		"district":         place.Address.District,
+		"city":             place.Address.City,
+		"postalCode":       place.Address.PostalCode,
+		"county":           place.Address.CountyName,
+		"state":            place.Address.State,
+		"country":          place.Address.CountryCode,
+	}
+	row := make([]string, 0, len(j.outCols))
+	for _, col := range j.outCols {
+		row = append(row, values[col])
+	}
+	return row
+}
+
+func (s *Server) batchJob(w http.ResponseWriter, r *http.Request, resource string) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	id, sub, _ := strings.Cut(resource, "/")
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	if !ok || (sub != "" && sub != "result") {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusNotFound, "Unknown job "+resource)
+		return
+	}
+	if sub == "result" {
+		completed := job.pendingPolls == 0
+		s.mu.Unlock()
+		if !completed {
+			writeError(w, r, http.StatusNotFound, "Job "+id+" is not completed")
+			return
+		}
+		s.batchResult(w, r, id, job)
+		return
+	}
+	if action := r.URL.Query().Get("action"); action != "status" {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid action %q", action))
+		return
+	}
+	var response batchResponse
+	response.Response.MetaInfo.RequestID = id
+	response.Response.TotalCount = len(job.rows) + job.invalid
+	response.Response.ValidCount = len(job.rows)
+	response.Response.InvalidCount = job.invalid
+	if job.pendingPolls > 0 {
+		job.pendingPolls--
+		response.Response.Status = geocodingsearchv7.JobStatusRunning
+		response.Response.PendingCount = response.Response.TotalCount
+	} else {
+		response.Response.Status = geocodingsearchv7.JobStatusCompleted
+		response.Response.ProcessedCount = response.Response.TotalCount
+		response.Response.SuccessCount = len(job.rows)
+		response.Response.ErrorCount = job.invalid
+	}
+	s.mu.Unlock()
+	writeXML(w, http.StatusOK, response)
+}
+
+// batchResult writes the result of a completed job, as a zipped file of delimited rows.
+func (s *Server) batchResult(w http.ResponseWriter, r *http.Request, id string, job *batchJob) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	f, err := archive.Create(id + "_out.txt")
+	if err == nil {
+		_, err = io.WriteString(f, strings.Join(job.outCols, job.outDelim)+"\n")
+	}
+	for _, row := range job.rows {
+		if err == nil {
+			_, err = io.WriteString(f, strings.Join(row, job.outDelim)+"\n")
+		}
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
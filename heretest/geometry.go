@@ -0,0 +1,72 @@
+package heretest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadius is the mean radius of the earth, in meters.
+const earthRadius = 6371008.8
+
+type point struct {
+	lat float64
+	lng float64
+}
+
+// parsePoint parses a point of the form lat,lng, ignoring any ;-separated place options and !-separated waypoint
+// options after it.
+func parsePoint(s string) (point, error) {
+	if i := strings.IndexAny(s, ";!"); i >= 0 {
+		s = s[:i]
+	}
+	latStr, lngStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return point{}, fmt.Errorf("invalid coordinates %q", s)
+	}
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return point{}, fmt.Errorf("invalid latitude %q", latStr)
+	}
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return point{}, fmt.Errorf("invalid longitude %q", lngStr)
+	}
+	return point{lat: lat, lng: lng}, nil
+}
+
+// distance returns the great-circle distance between two points, in meters.
+func distance(a, b point) float64 {
+	lat1, lat2 := a.lat*math.Pi/180, b.lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.lng - a.lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// pathLength returns the length of a path through the points, in meters.
+func pathLength(points []point) float64 {
+	var length float64
+	for i := 1; i < len(points); i++ {
+		length += distance(points[i-1], points[i])
+	}
+	return length
+}
+
+// travelTime returns the time to travel the length at the speed of the server, in whole seconds.
+func (s *Server) travelTime(length float64) int32 {
+	return int32(math.Round(length / s.config.Speed))
+}
+
+// hashPoint derives a point from a string, so that the same string always gets the same point.
+func hashPoint(s string) point {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.ToLower(s)))
+	sum := h.Sum64()
+	// Keep to latitudes where straight lines are meaningful, and round to the precision of HERE responses.
+	lat := float64(sum%12_000_000)/100_000 - 60
+	lng := float64((sum/12_000_000)%36_000_000)/100_000 - 180
+	return point{lat: lat, lng: lng}
+}
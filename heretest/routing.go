@@ -0,0 +1,342 @@
+package heretest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.einride.tech/here/routingv8"
+)
+
+// waypoint is a point of a route.
+type waypoint struct {
+	point
+	// index of the requested waypoint, where 0 is the origin. Negative if the point is not a requested waypoint.
+	index int
+	// passThrough waypoints do not end a section.
+	passThrough bool
+}
+
+func (s *Server) routes(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("transportMode") == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing transportMode")
+		return
+	}
+	origin, err := parsePoint(query.Get("origin"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid origin: "+err.Error())
+		return
+	}
+	destination, err := parsePoint(query.Get("destination"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid destination: "+err.Error())
+		return
+	}
+	waypoints := []waypoint{{point: origin, index: 0}}
+	for i, via := range query["via"] {
+		p, err := parsePoint(via)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid via %d: %v", i, err))
+			return
+		}
+		waypoints = append(waypoints, waypoint{
+			point:       p,
+			index:       i + 1,
+			passThrough: strings.Contains(via, "!passThrough=true"),
+		})
+	}
+	waypoints = append(waypoints, waypoint{point: destination, index: len(waypoints)})
+	route, err := s.newRoute(waypoints, query.Get("return"), query.Get("departureTime"), query.Get("arrivalTime"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, routingv8.RoutesResponse{Routes: []routingv8.Route{route}})
+}
+
+func (s *Server) routeImport(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("transportMode") == "" {
+		writeError(w, r, http.StatusBadRequest, "Missing transportMode")
+		return
+	}
+	var body routingv8.RouteImportRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid body: "+err.Error())
+		return
+	}
+	if len(body.Trace) < 2 {
+		writeError(w, r, http.StatusBadRequest, "Trace must contain at least 2 points")
+		return
+	}
+	waypoints := make([]waypoint, 0, len(body.Trace))
+	for _, p := range body.Trace {
+		waypoints = append(waypoints, waypoint{point: point{lat: p.Lat, lng: p.Long}, index: -1, passThrough: true})
+	}
+	route, err := s.newRoute(waypoints, query.Get("return"), query.Get("departureTime"), query.Get("arrivalTime"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, routingv8.RoutesResponse{Routes: []routingv8.Route{route}})
+}
+
+// newRoute returns a route along straight lines between the waypoints, with a section ending at every waypoint
+// that is not a pass-through waypoint.
+func (s *Server) newRoute(
+	waypoints []waypoint,
+	returnParam, departureTime, arrivalTime string,
+) (routingv8.Route, error) {
+	returns := make(map[routingv8.ReturnAttribute]bool)
+	for _, attribute := range strings.Split(returnParam, ",") {
+		returns[routingv8.ReturnAttribute(attribute)] = true
+	}
+	if returnParam == "" {
+		returns[routingv8.SummaryReturnAttribute] = true
+	}
+	var sections [][]waypoint
+	current := []waypoint{waypoints[0]}
+	for i, wp := range waypoints[1:] {
+		current = append(current, wp)
+		if !wp.passThrough || i == len(waypoints)-2 {
+			sections = append(sections, current)
+			current = []waypoint{wp}
+		}
+	}
+	start, err := s.routeStart(sections, departureTime, arrivalTime)
+	if err != nil {
+		return routingv8.Route{}, err
+	}
+	route := routingv8.Route{ID: "route-1"}
+	t := start
+	for i, section := range sections {
+		points := make([]point, 0, len(section))
+		for _, wp := range section {
+			points = append(points, wp.point)
+		}
+		length := pathLength(points)
+		duration := s.travelTime(length)
+		result := routingv8.Section{
+			ID:        fmt.Sprintf("section-%d", i+1),
+			Type:      "vehicle",
+			Departure: routingv8.VehicleDeparture{Place: newPlace(section[0])},
+			Arrival:   routingv8.VehicleDeparture{Place: newPlace(section[len(section)-1])},
+		}
+		if !t.IsZero() {
+			result.Departure.Time = t.Format(time.RFC3339)
+			t = t.Add(time.Duration(duration) * time.Second)
+			result.Arrival.Time = t.Format(time.RFC3339)
+		}
+		if returns[routingv8.SummaryReturnAttribute] {
+			result.Summary = routingv8.Summary{Duration: duration, Length: int32(length), BaseDuration: duration}
+		}
+		if returns[routingv8.PolylineReturnAttribute] {
+			if result.Polyline, err = encodePolyline(points); err != nil {
+				return routingv8.Route{}, err
+			}
+		}
+		if returns[routingv8.ActionsReturnAttribute] || returns[routingv8.TurnByTurnActionsReturnAttribute] {
+			actions := newActions(duration, int32(length), len(points)-1, returns[routingv8.InstructionsReturnAttribute])
+			if returns[routingv8.ActionsReturnAttribute] {
+				result.Actions = actions
+			}
+			if returns[routingv8.TurnByTurnActionsReturnAttribute] {
+				result.TurnByTurnActions = actions
+			}
+		}
+		route.Sections = append(route.Sections, result)
+	}
+	return route, nil
+}
+
+// routeStart returns the departure time of a route, or the zero time if the route is not time-aware.
+func (s *Server) routeStart(sections [][]waypoint, departureTime, arrivalTime string) (time.Time, error) {
+	switch {
+	case departureTime != "" && arrivalTime != "":
+		return time.Time{}, fmt.Errorf("departureTime and arrivalTime can not both be set")
+	case departureTime != "" && departureTime != routingv8.DepartureTimeAny:
+		start, err := time.Parse(time.RFC3339, departureTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid departureTime %q", departureTime)
+		}
+		return start, nil
+	case arrivalTime != "":
+		end, err := time.Parse(time.RFC3339, arrivalTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid arrivalTime %q", arrivalTime)
+		}
+		var total int32
+		for _, section := range sections {
+			points := make([]point, 0, len(section))
+			for _, wp := range section {
+				points = append(points, wp.point)
+			}
+			total += s.travelTime(pathLength(points))
+		}
+		return end.Add(-time.Duration(total) * time.Second), nil
+	}
+	return time.Time{}, nil
+}
+
+func newPlace(wp waypoint) routingv8.Place {
+	location := routingv8.GeoWaypoint{Lat: wp.lat, Long: wp.lng}
+	place := routingv8.Place{Type: "place", Location: location, OriginalLocation: location}
+	if wp.index >= 0 {
+		index := wp.index
+		place.Waypoint = &index
+	}
+	return place
+}
+
+// newActions returns the actions of a straight section: depart, followed by arrive at the last point.
+func newActions(duration, length int32, lastOffset int, instructions bool) []routingv8.Action {
+	actions := []routingv8.Action{
+		{Action: routingv8.ActionTypeDepart, Duration: duration, Length: length},
+		{Action: routingv8.ActionTypeArrive, Offset: lastOffset},
+	}
+	if instructions {
+		actions[0].Instruction = "Head toward your destination."
+		actions[1].Instruction = "Arrive at your destination."
+	}
+	return actions
+}
+
+func encodePolyline(points []point) (routingv8.Polyline, error) {
+	waypoints := make([]routingv8.GeoWaypoint, 0, len(points))
+	for _, p := range points {
+		waypoints = append(waypoints, routingv8.GeoWaypoint{Lat: p.lat, Long: p.lng})
+	}
+	return routingv8.EncodePolyline(waypoints, routingv8.PolylineHeader{Precision: routingv8.DefaultPolylinePrecision})
+}
+
+// matrixBody is the request body of a matrix calculation.
+type matrixBody struct {
+	Origins          []routingv8.GeoWaypoint `json:"origins"`
+	Destinations     []routingv8.GeoWaypoint `json:"destinations"`
+	RegionDefinition json.RawMessage         `json:"regionDefinition"`
+	MatrixAttributes []string                `json:"matrixAttributes"`
+}
+
+// matrixResult is the response body of a completed matrix calculation.
+type matrixResult struct {
+	MatrixID         string                   `json:"matrixId"`
+	Matrix           routingv8.MatrixResponse `json:"matrix"`
+	RegionDefinition json.RawMessage          `json:"regionDefinition"`
+}
+
+// matrixJob is an asynchronous matrix calculation.
+type matrixJob struct {
+	result       matrixResult
+	pendingPolls int
+}
+
+func (s *Server) matrix(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	var body matrixBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid body: "+err.Error())
+		return
+	}
+	if len(body.Origins) == 0 {
+		writeError(w, r, http.StatusBadRequest, "Missing origins")
+		return
+	}
+	if len(body.RegionDefinition) == 0 {
+		writeError(w, r, http.StatusBadRequest, "Missing regionDefinition")
+		return
+	}
+	if len(body.Destinations) == 0 {
+		body.Destinations = body.Origins
+	}
+	attributes := map[string]bool{}
+	for _, attribute := range body.MatrixAttributes {
+		if attribute != "travelTimes" && attribute != "distances" {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid matrixAttributes %q", attribute))
+			return
+		}
+		attributes[attribute] = true
+	}
+	if len(attributes) == 0 {
+		attributes["travelTimes"] = true
+	}
+	matrix := routingv8.MatrixResponse{
+		NumOrigins:      len(body.Origins),
+		NumDestinations: len(body.Destinations),
+		ErrorCodes:      make(routingv8.ErrorCodes, 0, len(body.Origins)*len(body.Destinations)),
+	}
+	for _, origin := range body.Origins {
+		for _, destination := range body.Destinations {
+			length := distance(point{lat: origin.Lat, lng: origin.Long}, point{lat: destination.Lat, lng: destination.Long})
+			if attributes["travelTimes"] {
+				matrix.TravelTimes = append(matrix.TravelTimes, s.travelTime(length))
+			}
+			if attributes["distances"] {
+				matrix.Distances = append(matrix.Distances, int32(length))
+			}
+			matrix.ErrorCodes = append(matrix.ErrorCodes, routingv8.ErrorCodeSuccess)
+		}
+	}
+	s.mu.Lock()
+	id := s.nextID("matrix")
+	result := matrixResult{MatrixID: id, Matrix: matrix, RegionDefinition: body.RegionDefinition}
+	if r.URL.Query().Get("async") != "true" {
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+	s.matrices[id] = &matrixJob{result: result, pendingPolls: s.config.PendingPolls}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusAccepted, routingv8.MatrixStatusResponse{
+		MatrixID:  id,
+		Status:    routingv8.MatrixStatusAccepted,
+		StatusURL: s.URL() + "/v8/matrix/" + id + "/status",
+	})
+}
+
+func (s *Server) matrixJob(w http.ResponseWriter, r *http.Request, resource string) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	id, sub, _ := strings.Cut(resource, "/")
+	s.mu.Lock()
+	job, ok := s.matrices[id]
+	if !ok || (sub != "" && sub != "status") {
+		s.mu.Unlock()
+		writeError(w, r, http.StatusNotFound, "Unknown matrix "+resource)
+		return
+	}
+	if sub == "" {
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, job.result)
+		return
+	}
+	if job.pendingPolls > 0 {
+		job.pendingPolls--
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, routingv8.MatrixStatusResponse{
+			MatrixID:  id,
+			Status:    routingv8.MatrixStatusInProgress,
+			StatusURL: s.URL() + r.URL.Path,
+		})
+		return
+	}
+	s.mu.Unlock()
+	resultURL := s.URL() + "/v8/matrix/" + id
+	w.Header().Set("Location", resultURL)
+	writeJSON(w, http.StatusSeeOther, routingv8.MatrixStatusResponse{
+		MatrixID:  id,
+		Status:    routingv8.MatrixStatusCompleted,
+		ResultURL: resultURL,
+	})
+}
@@ -0,0 +1,241 @@
+// Package heretest provides an in-process fake of the HERE APIs for integration tests.
+//
+// The fake server implements the v8 Routing API (routes and import), the v8 Matrix Routing API (sync and async),
+// the v7 Geocoding & Search API (geocode, revgeocode, autosuggest, discover, browse and lookup) and the v6.2 Batch
+// Geocoder API (job lifecycle). Point a client to it with the WithBaseURL option of the client package:
+//
+//	server := heretest.NewServer(heretest.Config{})
+//	defer server.Close()
+//	routingClient := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+//
+// Responses are deterministic. Routes follow straight lines between their waypoints, distances are great-circle
+// distances and travel times are derived from Config.Speed. Parameters that do not affect straight lines, such as
+// alternatives, avoidances and vehicle restrictions, are accepted and ignored. Faults, such as 429 responses,
+// latency or malformed bodies, can be injected with Server.InjectFault.
+package heretest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.einride.tech/here/geocodingsearchv7"
+)
+
+// DefaultSpeed is the speed used to derive travel times from distances, in meters per second.
+const DefaultSpeed = 20
+
+// Config configures a Server.
+type Config struct {
+	// Speed used to derive travel times from distances, in meters per second. Defaults to DefaultSpeed.
+	Speed float64
+	// PendingPolls is the number of status requests an asynchronous matrix calculation or batch geocoder job
+	// stays in progress before it is completed. Zero completes them on the first status request.
+	PendingPolls int
+	// APIKey required as the apiKey query parameter of every request. Not checked if empty, unless Token is set.
+	APIKey string
+	// Token accepted as a bearer token in the Authorization header of every request. Not checked if empty,
+	// unless APIKey is set.
+	Token string
+	// Places known to the server. Geocoding returns the place with a title or address label equal to the query,
+	// and reverse geocoding returns the place closest to the position. Other queries and positions are answered
+	// with a place derived from the query or position. Autosuggest and discover return the places with a title or
+	// address label containing the query, browse the places with one of the categories, and lookup the place with
+	// the ID.
+	Places []Place
+}
+
+// Place is a place known to the Server.
+type Place struct {
+	// ID of the place. Derived from the title if empty.
+	ID string
+	// Title of the place, e.g. a name or a complete address.
+	Title string
+	// ResultType of the place, e.g. houseNumber or place. Defaults to houseNumber.
+	ResultType string
+	// Position of the place.
+	Position geocodingsearchv7.GeoWaypoint
+	// Address of the place.
+	Address geocodingsearchv7.Address
+	// Categories of the place, found by discover and browse.
+	Categories []geocodingsearchv7.Category
+}
+
+// Request is a request received by the Server.
+type Request struct {
+	// Method of the request.
+	Method string
+	// Path of the request.
+	Path string
+	// Query of the request.
+	Query url.Values
+}
+
+// Server is a fake HERE API server.
+type Server struct {
+	config Config
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []Request
+	faults   []*fault
+	ids      int
+	matrices map[string]*matrixJob
+	jobs     map[string]*batchJob
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer(config Config) *Server {
+	if config.Speed <= 0 {
+		config.Speed = DefaultSpeed
+	}
+	s := &Server{
+		config:   config,
+		matrices: make(map[string]*matrixJob),
+		jobs:     make(map[string]*batchJob),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests on this server have completed.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL of the server, of the form http://ipaddr:port with no trailing slash.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// BaseURL of the server, to pass to the WithBaseURL option of a client.
+func (s *Server) BaseURL() *url.URL {
+	u, err := url.Parse(s.server.URL)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Requests returns the requests received by the server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()})
+	f := s.nextFault(r)
+	s.mu.Unlock()
+	if f != nil {
+		if !f.apply(w, r, s.route) {
+			return
+		}
+	}
+	s.route(w, r)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, r, http.StatusUnauthorized, "Missing or invalid credentials")
+		return
+	}
+	path := r.URL.Path
+	switch {
+	case path == "/v8/routes":
+		s.routes(w, r)
+	case path == "/v8/import":
+		s.routeImport(w, r)
+	case path == "/v8/matrix":
+		s.matrix(w, r)
+	case strings.HasPrefix(path, "/v8/matrix/"):
+		s.matrixJob(w, r, strings.TrimPrefix(path, "/v8/matrix/"))
+	case path == "/v1/geocode":
+		s.geocode(w, r)
+	case path == "/v1/revgeocode":
+		s.reverseGeocode(w, r)
+	case path == "/v1/autosuggest":
+		s.autosuggest(w, r)
+	case path == "/v1/discover":
+		s.discover(w, r)
+	case path == "/v1/browse":
+		s.browse(w, r)
+	case path == "/v1/lookup":
+		s.lookup(w, r)
+	case path == "/6.2/jobs":
+		s.batchUpload(w, r)
+	case strings.HasPrefix(path, "/6.2/jobs/"):
+		s.batchJob(w, r, strings.TrimPrefix(path, "/6.2/jobs/"))
+	default:
+		writeError(w, r, http.StatusNotFound, "Unknown resource "+path)
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.config.APIKey == "" && s.config.Token == "" {
+		return true
+	}
+	if s.config.APIKey != "" && r.URL.Query().Get("apiKey") == s.config.APIKey {
+		return true
+	}
+	return s.config.Token != "" && r.Header.Get("Authorization") == "Bearer "+s.config.Token
+}
+
+// nextID returns a new identifier with the given prefix. Must be called with mu held.
+func (s *Server) nextID(prefix string) string {
+	s.ids++
+	return prefix + "-" + strconv.Itoa(s.ids)
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		writeError(w, r, http.StatusMethodNotAllowed, "Method "+r.Method+" not allowed")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeXML(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// hereError is the error body returned by the HERE APIs.
+type hereError struct {
+	XMLName xml.Name `json:"-" xml:"error"`
+	Title   string   `json:"title" xml:"title"`
+	Status  int      `json:"status" xml:"status"`
+	Code    string   `json:"code" xml:"code"`
+	Cause   string   `json:"cause" xml:"cause"`
+	Action  string   `json:"action" xml:"action"`
+}
+
+// writeError writes a HERE error body, as XML for the batch geocoder and as JSON otherwise.
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, cause string) {
+	body := hereError{
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Code:   "E605" + strconv.Itoa(statusCode),
+		Cause:  cause,
+	}
+	if strings.HasPrefix(r.URL.Path, "/6.2/") {
+		writeXML(w, statusCode, body)
+		return
+	}
+	writeJSON(w, statusCode, body)
+}
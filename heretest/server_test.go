@@ -0,0 +1,438 @@
+package heretest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/heretest"
+	"go.einride.tech/here/retry"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+var (
+	// Einride Gothenburg.
+	gothenburg = routingv8.GeoWaypoint{Lat: 57.70775, Long: 11.94977}
+	// Einride Stockholm.
+	stockholm = routingv8.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	// Jönköping, roughly halfway.
+	jonkoping = routingv8.GeoWaypoint{Lat: 57.78261, Long: 14.16179}
+)
+
+func TestServer_Routes(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{})
+	t.Cleanup(server.Close)
+	client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+	for _, tt := range []struct {
+		name             string
+		via              []routingv8.Via
+		expectedSections int
+	}{
+		{name: "direct", expectedSections: 1},
+		{name: "via", via: []routingv8.Via{{Location: jonkoping}}, expectedSections: 2},
+		{name: "pass-through via", via: []routingv8.Via{{Location: jonkoping, PassThrough: true}}, expectedSections: 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			response, err := client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+				Origin:        gothenburg,
+				Destination:   stockholm,
+				Via:           tt.via,
+				TransportMode: routingv8.TransportModeTruck,
+				Return:        []routingv8.ReturnAttribute{routingv8.SummaryReturnAttribute, routingv8.PolylineReturnAttribute},
+				DepartureTime: "2024-01-02T08:00:00Z",
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, len(response.Routes), 1)
+			sections := response.Routes[0].Sections
+			assert.Equal(t, len(sections), tt.expectedSections)
+			assert.Equal(t, sections[0].Departure.Place.Location, gothenburg)
+			assert.Equal(t, *sections[0].Departure.Place.Waypoint, 0)
+			last := sections[len(sections)-1]
+			assert.Equal(t, last.Arrival.Place.Location, stockholm)
+			assert.Equal(t, *last.Arrival.Place.Waypoint, len(tt.via)+1)
+			assert.Equal(t, sections[0].Departure.Time, "2024-01-02T08:00:00Z")
+			var length, duration int32
+			for _, section := range sections {
+				length += section.Summary.Length
+				duration += section.Summary.Duration
+				waypoints, err := section.Polyline.Decode()
+				assert.NilError(t, err)
+				assert.Equal(t, waypoints[0], section.Departure.Place.Location)
+				assert.Equal(t, waypoints[len(waypoints)-1], section.Arrival.Place.Location)
+			}
+			// The great-circle distance from Gothenburg to Stockholm is 397 km, at 20 m/s.
+			assert.Assert(t, length >= 397_000 && length < 420_000, length)
+			assert.Assert(t, duration >= length/20-1 && duration <= length/20+1, duration)
+			arrival, err := time.Parse(time.RFC3339, last.Arrival.Time)
+			assert.NilError(t, err)
+			assert.Equal(t, arrival.Sub(time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)), time.Duration(duration)*time.Second)
+		})
+	}
+}
+
+func TestServer_RouteImport(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{Speed: 10})
+	t.Cleanup(server.Close)
+	client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+	response, err := client.Routing.RouteImport(context.Background(), &routingv8.RouteImportRequest{
+		Trace:         []routingv8.GeoWaypoint{gothenburg, jonkoping, stockholm},
+		TransportMode: routingv8.TransportModeCar,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Routes), 1)
+	assert.Equal(t, len(response.Routes[0].Sections), 1)
+	summary := response.Routes[0].Sections[0].Summary
+	assert.Assert(t, summary.Length > 397_000)
+	assert.Equal(t, summary.Duration, (summary.Length+5)/10)
+}
+
+func TestServer_Matrix(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{PendingPolls: 2})
+	t.Cleanup(server.Close)
+	client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+	request := &routingv8.CalculateMatrixRequest{
+		Body: &routingv8.CalculateMatrixBody{
+			Origins:          []*routingv8.GeoWaypoint{&gothenburg, &stockholm},
+			Destinations:     []*routingv8.GeoWaypoint{&stockholm, &jonkoping, &gothenburg},
+			RegionDefinition: routingv8.RegionDefinition{Type: routingv8.RegionTypeWorld},
+			MatrixAttributes: &routingv8.MatrixAttributes{
+				routingv8.MatrixAttributeTravelTimes,
+				routingv8.MatrixAttributeDistances,
+			},
+		},
+	}
+	t.Run("sync", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Matrix.CalculateMatrix(context.Background(), request)
+		assert.NilError(t, err)
+		assert.Equal(t, response.RegionDefinition.Type, routingv8.RegionType(routingv8.RegionTypeWorld))
+		matrix := response.Matrix
+		assert.Equal(t, matrix.NumOrigins, 2)
+		assert.Equal(t, matrix.NumDestinations, 3)
+		assert.Equal(t, len(matrix.UnreachableCells()), 0)
+		// Distances are symmetric, and zero from a point to itself.
+		assert.Equal(t, matrix.Cell(0, 0).DistanceMeters, matrix.Cell(1, 2).DistanceMeters)
+		assert.Equal(t, matrix.Cell(0, 2).DistanceMeters, int32(0))
+		assert.Equal(t, matrix.Cell(0, 0).TravelTime, time.Duration(matrix.Cell(0, 0).DistanceMeters/20)*time.Second)
+	})
+	t.Run("async", func(t *testing.T) {
+		t.Parallel()
+		status, err := client.Matrix.CalculateMatrixAsync(context.Background(), request)
+		assert.NilError(t, err)
+		assert.Equal(t, status.Status, routingv8.MatrixStatusAccepted)
+		response, err := client.Matrix.WaitForMatrix(context.Background(), &routingv8.WaitForMatrixRequest{
+			MatrixID:     status.MatrixID,
+			PollInterval: time.Millisecond,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, response.MatrixID, status.MatrixID)
+		assert.Equal(t, len(response.Matrix.Cells()), 6)
+		var polls int
+		for _, request := range server.Requests() {
+			if request.Path == "/v8/matrix/"+status.MatrixID+"/status" {
+				polls++
+			}
+		}
+		assert.Equal(t, polls, 3)
+	})
+}
+
+func TestServer_Geocoding(t *testing.T) {
+	t.Parallel()
+	office := heretest.Place{
+		Title:    "Regeringsgatan 65, 111 56 Stockholm, Sverige",
+		Position: geocodingsearchv7.GeoWaypoint{Lat: 59.337492, Long: 18.063672},
+		Address: geocodingsearchv7.Address{
+			CountryCode: "SWE",
+			City:        "Stockholm",
+			Street:      "Regeringsgatan",
+			HouseNumber: "65",
+		},
+	}
+	server := heretest.NewServer(heretest.Config{Places: []heretest.Place{office}})
+	t.Cleanup(server.Close)
+	client := geocodingsearchv7.NewClient(nil, geocodingsearchv7.WithBaseURL(server.BaseURL()))
+	ctx := context.Background()
+	t.Run("known place", func(t *testing.T) {
+		t.Parallel()
+		q := "regeringsgatan 65, 111 56 stockholm, sverige"
+		response, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, office.Title)
+		assert.Equal(t, response.Items[0].Position, office.Position)
+		assert.Equal(t, response.Items[0].Address.Street, "Regeringsgatan")
+	})
+	t.Run("outside countries", func(t *testing.T) {
+		t.Parallel()
+		q, in := office.Title, geocodingsearchv7.InCountries("NOR")
+		response, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q, Area: in})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 0)
+	})
+	t.Run("derived place", func(t *testing.T) {
+		t.Parallel()
+		q := "Somewhere 1"
+		first, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+		second, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, first, second)
+		assert.Equal(t, first.Items[0].Title, q)
+	})
+	t.Run("reverse", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.ReverseGeocoding.ReverseGeocoding(ctx, &geocodingsearchv7.ReverseGeocodingRequest{
+			GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.3376, Long: 18.0637},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, office.Title)
+		assert.Equal(t, response.Items[0].Distance, 12)
+	})
+	t.Run("reverse in circle", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.ReverseGeocoding.ReverseGeocoding(ctx, &geocodingsearchv7.ReverseGeocodingRequest{
+			Area: geocodingsearchv7.InCircle(geocodingsearchv7.GeoWaypoint{Lat: 59.3376, Long: 18.0637}, 500),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, office.Title)
+		assert.Equal(t, response.Items[0].Distance, 12)
+	})
+	t.Run("autosuggest", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Autosuggest.Autosuggest(ctx, &geocodingsearchv7.AutosuggestRequest{
+			Q:           "gatan 6",
+			GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.3376, Long: 18.0637},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		item := response.Items[0]
+		assert.Equal(t, item.Title, office.Title)
+		assert.Equal(t, item.ResultType, geocodingsearchv7.ResultTypeHouseNumber)
+		assert.Equal(t, item.Highlights.Title[0].Substring(item.Title), "gatan 6")
+		assert.Equal(t, item.Distance, 12)
+	})
+	t.Run("autosuggest outside countries", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Autosuggest.Autosuggest(ctx, &geocodingsearchv7.AutosuggestRequest{
+			Q:           "gatan",
+			GeoPosition: &geocodingsearchv7.GeoWaypoint{Lat: 59.3376, Long: 18.0637},
+			Area:        geocodingsearchv7.InCountries("NOR"),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 0)
+	})
+}
+
+func TestServer_PlaceSearch(t *testing.T) {
+	t.Parallel()
+	truckStop := heretest.Place{
+		Title:      "Rasta Järna",
+		ResultType: "place",
+		Position:   geocodingsearchv7.GeoWaypoint{Lat: 59.0935, Long: 17.5683},
+		Address:    geocodingsearchv7.Address{CountryCode: "SWE", City: "Järna"},
+		Categories: []geocodingsearchv7.Category{
+			{ID: geocodingsearchv7.CategoryTruckStop, Name: "Truck Stop/Plaza", Primary: true},
+		},
+	}
+	fuelStation := heretest.Place{
+		Title:      "Circle K Kungens Kurva",
+		ResultType: "place",
+		Position:   geocodingsearchv7.GeoWaypoint{Lat: 59.2697, Long: 17.9180},
+		Address:    geocodingsearchv7.Address{CountryCode: "SWE", City: "Huddinge"},
+		Categories: []geocodingsearchv7.Category{
+			{ID: geocodingsearchv7.CategoryPetrolStation, Name: "Petrol/Gasoline Station", Primary: true},
+		},
+	}
+	server := heretest.NewServer(heretest.Config{Places: []heretest.Place{truckStop, fuelStation}})
+	t.Cleanup(server.Close)
+	client := geocodingsearchv7.NewClient(nil, geocodingsearchv7.WithBaseURL(server.BaseURL()))
+	ctx := context.Background()
+	at := &geocodingsearchv7.GeoWaypoint{Lat: stockholm.Lat, Long: stockholm.Long}
+	t.Run("discover by category name", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Discover.Discover(ctx, &geocodingsearchv7.DiscoverRequest{
+			Q:           "truck stop",
+			GeoPosition: at,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, truckStop.Title)
+		assert.Equal(t, response.Items[0].Distance, 39125)
+	})
+	t.Run("browse by distance", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Browse.Browse(ctx, &geocodingsearchv7.BrowseRequest{GeoPosition: at})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 2)
+		assert.Equal(t, response.Items[0].Title, fuelStation.Title)
+		assert.Equal(t, response.Items[1].Title, truckStop.Title)
+	})
+	t.Run("browse by category", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Browse.Browse(ctx, &geocodingsearchv7.BrowseRequest{
+			GeoPosition: at,
+			Categories:  geocodingsearchv7.TruckParkingCategories,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, truckStop.Title)
+		assert.Assert(t, response.Items[0].HasCategory(geocodingsearchv7.CategoryTruckServices))
+		place, err := client.Lookup.Lookup(ctx, &geocodingsearchv7.LookupRequest{ID: response.Items[0].ID})
+		assert.NilError(t, err)
+		assert.Equal(t, place.Title, truckStop.Title)
+		assert.DeepEqual(t, place.Categories, truckStop.Categories)
+	})
+	t.Run("lookup unknown id", func(t *testing.T) {
+		t.Parallel()
+		_, err := client.Lookup.Lookup(ctx, &geocodingsearchv7.LookupRequest{ID: "here:heretest:unknown"})
+		var responseError *geocodingsearchv7.ResponseError
+		assert.Assert(t, errors.As(err, &responseError))
+		assert.Equal(t, responseError.HTTPStatusCode, http.StatusNotFound)
+	})
+	t.Run("browse with limit", func(t *testing.T) {
+		t.Parallel()
+		response, err := client.Browse.Browse(ctx, &geocodingsearchv7.BrowseRequest{
+			GeoPosition: at,
+			Categories:  []geocodingsearchv7.CategoryID{geocodingsearchv7.CategoryBusinessAndServices},
+			Limit:       1,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(response.Items), 1)
+		assert.Equal(t, response.Items[0].Title, fuelStation.Title)
+	})
+}
+
+func TestServer_BatchGeocoding(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{PendingPolls: 1})
+	t.Cleanup(server.Close)
+	client := geocodingsearchv7.NewClient(nil, geocodingsearchv7.WithBaseURL(server.BaseURL()))
+	ctx := context.Background()
+	upload, err := client.BatchGeocoding.BatchGeocoderUpload(ctx, &geocodingsearchv7.BatchGeocoderUploadRequest{
+		Queries: []*geocodingsearchv7.QueryString{
+			{RecID: "1", Query: "Regeringsgatan 65, Stockholm", Country: "SWE"},
+			{RecID: "2", Query: "Lindholmspiren 3, Göteborg", Country: "SWE"},
+		},
+	})
+	assert.NilError(t, err)
+	requestID := upload.Response.MetaInfo.RequestID
+	assert.Equal(t, upload.Response.Status, geocodingsearchv7.JobStatus(geocodingsearchv7.JobStatusAccepted))
+	assert.Equal(t, upload.Response.TotalCount, 2)
+	// The result is not available until the job is completed.
+	var result bytes.Buffer
+	downloadRequest := &geocodingsearchv7.BatchGeocoderDownloadRequest{RequestID: requestID}
+	err = client.BatchGeocoding.BatchGeocoderDownload(ctx, downloadRequest, &result)
+	var responseError *geocodingsearchv7.ResponseError
+	assert.Assert(t, errors.As(err, &responseError))
+	assert.Equal(t, responseError.HTTPStatusCode, http.StatusNotFound)
+	statusRequest := &geocodingsearchv7.BatchGeocoderStatusRequest{RequestID: requestID}
+	status, err := client.BatchGeocoding.BatchGeocoderStatus(ctx, statusRequest)
+	assert.NilError(t, err)
+	assert.Equal(t, status.Response.Status, geocodingsearchv7.JobStatus(geocodingsearchv7.JobStatusRunning))
+	status, err = client.BatchGeocoding.BatchGeocoderStatus(ctx, statusRequest)
+	assert.NilError(t, err)
+	assert.Equal(t, status.Response.Status, geocodingsearchv7.JobStatus(geocodingsearchv7.JobStatusCompleted))
+	assert.Equal(t, status.Response.SuccessCount, 2)
+	assert.NilError(t, client.BatchGeocoding.BatchGeocoderDownload(ctx, downloadRequest, &result))
+	archive, err := zip.NewReader(bytes.NewReader(result.Bytes()), int64(result.Len()))
+	assert.NilError(t, err)
+	assert.Equal(t, len(archive.File), 1)
+	f, err := archive.File[0].Open()
+	assert.NilError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Assert(t, strings.HasPrefix(lines[0], "recId|SeqNumber|seqLength|displayLatitude|displayLongitude|"))
+	assert.Assert(t, strings.HasPrefix(lines[1], "1|1|1|"))
+	assert.Assert(t, strings.HasSuffix(lines[2], "|SWE"))
+}
+
+func TestServer_Faults(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	request := &routingv8.RoutesRequest{
+		Origin:        gothenburg,
+		Destination:   stockholm,
+		TransportMode: routingv8.TransportModeCar,
+	}
+	t.Run("status code", func(t *testing.T) {
+		t.Parallel()
+		server := heretest.NewServer(heretest.Config{})
+		defer server.Close()
+		server.InjectFault(heretest.Fault{Path: "/v8/routes", StatusCode: http.StatusTooManyRequests, Count: 2})
+		client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+		_, err := client.Routing.Routes(ctx, request)
+		var responseError *routingv8.ResponseError
+		assert.Assert(t, errors.As(err, &responseError))
+		assert.Equal(t, responseError.HTTPStatusCode, http.StatusTooManyRequests)
+		assert.Assert(t, errors.Is(err, routingv8.ErrRateLimited))
+		assert.Equal(t, responseError.Response.Cause, "Injected fault")
+		retryClient := routingv8.NewClient(
+			nil,
+			routingv8.WithBaseURL(server.BaseURL()),
+			routingv8.WithMiddleware(func(next routingv8.HTTPClient) routingv8.HTTPClient {
+				return retry.NewHTTPClient(next, retry.Config{InitialBackoff: time.Millisecond})
+			}),
+		)
+		_, err = retryClient.Routing.Routes(ctx, request)
+		assert.NilError(t, err)
+		assert.Equal(t, len(server.Requests()), 3)
+	})
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+		server := heretest.NewServer(heretest.Config{})
+		defer server.Close()
+		server.InjectFault(heretest.Fault{Latency: time.Minute})
+		client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := client.Routing.Routes(ctx, request)
+		assert.Assert(t, errors.Is(err, context.DeadlineExceeded), err)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		server := heretest.NewServer(heretest.Config{})
+		defer server.Close()
+		server.InjectFault(heretest.Fault{Malformed: true, Count: 1})
+		client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+		_, err := client.Routing.Routes(ctx, request)
+		assert.ErrorContains(t, err, "failed to json decode")
+		_, err = client.Routing.Routes(ctx, request)
+		assert.NilError(t, err)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		t.Parallel()
+		server := heretest.NewServer(heretest.Config{APIKey: "key"})
+		defer server.Close()
+		client := routingv8.NewClient(nil, routingv8.WithBaseURL(server.BaseURL()))
+		_, err := client.Routing.Routes(ctx, request)
+		var responseError *routingv8.ResponseError
+		assert.Assert(t, errors.As(err, &responseError))
+		assert.Equal(t, responseError.HTTPStatusCode, http.StatusUnauthorized)
+		assert.Assert(t, errors.Is(err, routingv8.ErrUnauthorized))
+		client = routingv8.NewClient(
+			routingv8.NewAPIKeyHTTPClient("key", nil),
+			routingv8.WithBaseURL(server.BaseURL()),
+		)
+		_, err = client.Routing.Routes(ctx, request)
+		assert.NilError(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Decoder decodes a response body into v.
+type Decoder func(r io.Reader, v interface{}) error
+
+// DecodeJSON decodes a JSON response body.
+func DecodeJSON(r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to json decode: %w", err)
+	}
+	return nil
+}
+
+// DecodeXML decodes an XML response body.
+func DecodeXML(r io.Reader, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("xml unmarshal failed: %w", err)
+	}
+	return nil
+}
+
+// NewRequest creates an API request to u. A raw query string can be specified by rawQuery, and a JSON body by
+// body. The User-Agent header is set to userAgent, unless it is empty.
+func NewRequest(
+	ctx context.Context,
+	userAgent string,
+	u *url.URL,
+	method string,
+	rawQuery string,
+	body []byte,
+) (*http.Request, error) {
+	if len(rawQuery) > 0 {
+		u.RawQuery = rawQuery
+	}
+	var r io.Reader
+	if len(body) > 0 {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	return req, nil
+}
+
+// Do sends an API request with client. A response with a status code outside the 200 range is returned as the
+// error built by newError from the response and its body. Otherwise the response is decoded into v with decode,
+// or, if v implements io.Writer, the raw response is written to v.
+func Do(
+	client HTTPClient,
+	req *http.Request,
+	v interface{},
+	decode Decoder,
+	newError func(resp *http.Response, body []byte) error,
+) (err error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rerr := resp.Body.Close(); err == nil {
+			err = rerr
+		}
+	}()
+	if c := resp.StatusCode; c < 200 || c > 299 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return newError(resp, body)
+	}
+	if v == nil {
+		return nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}
+	return decode(resp.Body, v)
+}
@@ -0,0 +1,382 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.einride.tech/here/internal/core"
+	"go.einride.tech/here/telemetry"
+	"gotest.tools/v3/assert"
+)
+
+type ResponseMock struct {
+	statusCode int
+	body       string
+	requests   []*http.Request
+}
+
+func (r *ResponseMock) Do(req *http.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+	return &http.Response{
+		StatusCode: r.statusCode,
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
+func (r *ResponseMock) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.Do(req)
+}
+
+type middlewareFunc func(req *http.Request) (*http.Response, error)
+
+func (f middlewareFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestOptions_ServiceURL(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: "https://router.hereapi.com/v8/",
+		},
+		{
+			name:     "host",
+			baseURL:  "http://127.0.0.1:8080",
+			expected: "http://127.0.0.1:8080/v8/",
+		},
+		{
+			name:     "host and path",
+			baseURL:  "http://proxy.example.com/here/",
+			expected: "http://proxy.example.com/here/v8/",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var opts []core.Option
+			if tt.baseURL != "" {
+				baseURL, err := url.Parse(tt.baseURL)
+				assert.NilError(t, err)
+				opts = append(opts, core.WithBaseURL(baseURL))
+			}
+			o := core.NewOptions(nil, opts)
+			assert.Equal(t, o.ServiceURL("https://router.hereapi.com/v8/").String(), tt.expected)
+			u, err := o.ServiceURL("https://router.hereapi.com/v8/").Parse("routes")
+			assert.NilError(t, err)
+			assert.Equal(t, u.String(), tt.expected+"routes")
+		})
+	}
+}
+
+func TestOptions_Client(t *testing.T) {
+	t.Parallel()
+	mock := ResponseMock{statusCode: http.StatusOK}
+	var calls []string
+	middleware := func(name string) core.Middleware {
+		return func(next core.HTTPClient) core.HTTPClient {
+			return middlewareFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.Do(req)
+			})
+		}
+	}
+	o := core.NewOptions(
+		&ResponseMock{},
+		[]core.Option{
+			core.WithHTTPClient(&mock),
+			core.WithMiddleware(middleware("first")),
+			core.WithMiddleware(middleware("second")),
+			core.WithUserAgent("test"),
+		},
+	)
+	req, err := core.NewRequest(
+		context.Background(), o.UserAgent, o.ServiceURL("https://router.hereapi.com/v8/"), http.MethodGet, "", nil,
+	)
+	assert.NilError(t, err)
+	_, err = o.Client().Do(req)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, calls, []string{"first", "second"})
+	assert.Equal(t, len(mock.requests), 1)
+	assert.Equal(t, mock.requests[0].Header.Get("User-Agent"), "test")
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	newError := func(resp *http.Response, body []byte) error {
+		return errors.New(resp.Status + " " + string(body))
+	}
+	for _, tt := range []struct {
+		name     string
+		mock     ResponseMock
+		expected map[string]string
+		errStr   string
+	}{
+		{
+			name:     "success",
+			mock:     ResponseMock{statusCode: http.StatusOK, body: `{"a":"b"}`},
+			expected: map[string]string{"a": "b"},
+		},
+		{
+			name:   "error body",
+			mock:   ResponseMock{statusCode: http.StatusBadGateway, body: "<html>Bad Gateway</html>"},
+			errStr: " <html>Bad Gateway</html>",
+		},
+		{
+			name:   "malformed body",
+			mock:   ResponseMock{statusCode: http.StatusOK, body: `{"a":`},
+			errStr: "failed to json decode: unexpected EOF",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+			assert.NilError(t, err)
+			var got map[string]string
+			err = core.Do(&tt.mock, req, &got, core.DecodeJSON, newError)
+			if tt.errStr != "" {
+				assert.Error(t, err, tt.errStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.expected)
+		})
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+	mock := ResponseMock{statusCode: http.StatusOK, body: "ok"}
+	var calls int
+	transport := core.NewTransport(&mock, func(next core.HTTPClient) core.HTTPClient {
+		return middlewareFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return next.Do(req)
+		})
+	})
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get("https://example.com")
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, calls, 1)
+	assert.Equal(t, len(mock.requests), 1)
+}
+
+func TestRetries(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, core.Retries(&http.Response{Request: req}), 0)
+	retried := req.WithContext(core.WithRetries(req.Context(), 2))
+	assert.Equal(t, core.Retries(&http.Response{Request: retried}), 2)
+	assert.Equal(t, core.Retries(nil), 0)
+}
+
+func TestReadBody(t *testing.T) {
+	t.Parallel()
+	t.Run("without GetBody", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodPost, "https://example.com", io.NopCloser(strings.NewReader("body")),
+		)
+		assert.NilError(t, err)
+		assert.Assert(t, req.GetBody == nil)
+		body, err := core.ReadBody(req)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), "body")
+		// The body is restored, and can be reproduced for retries.
+		restored, err := io.ReadAll(req.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(restored), "body")
+		assert.Assert(t, req.GetBody != nil)
+		copied, err := req.GetBody()
+		assert.NilError(t, err)
+		data, err := io.ReadAll(copied)
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), "body")
+	})
+	t.Run("with GetBody", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(
+			context.Background(), http.MethodPost, "https://example.com", strings.NewReader("body"),
+		)
+		assert.NilError(t, err)
+		body, err := core.ReadBody(req)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), "body")
+		restored, err := io.ReadAll(req.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(restored), "body")
+	})
+	t.Run("no body", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+		assert.NilError(t, err)
+		body, err := core.ReadBody(req)
+		assert.NilError(t, err)
+		assert.Assert(t, body == nil)
+	})
+}
+
+func TestNewAPIKeyHTTPClient(t *testing.T) {
+	t.Parallel()
+	mock := ResponseMock{statusCode: http.StatusOK}
+	client := core.NewAPIKeyHTTPClient("key", &mock)
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "https://geocode.search.hereapi.com/v1/geocode?q=Stockholm", nil,
+	)
+	assert.NilError(t, err)
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, mock.requests[0].URL.RawQuery, "apiKey=key&q=Stockholm")
+	// The request of the caller is not modified.
+	assert.Equal(t, req.URL.RawQuery, "q=Stockholm")
+}
+
+type spanKey struct{}
+
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	attributes []telemetry.Attribute
+	errors     []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttributes(attributes ...telemetry.Attribute) {
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(
+	ctx context.Context,
+	operation string,
+	attributes ...telemetry.Attribute,
+) (context.Context, telemetry.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: operation, parent: parent, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type testMetrics struct {
+	measurements []telemetry.Measurement
+}
+
+func (m *testMetrics) Record(_ context.Context, measurement telemetry.Measurement) {
+	m.measurements = append(m.measurements, measurement)
+}
+
+func TestOptions_Client_Telemetry(t *testing.T) {
+	t.Parallel()
+	var tracer testTracer
+	var metrics testMetrics
+	mock := &ResponseMock{statusCode: http.StatusTooManyRequests, body: `{"title":"Too Many Requests"}`}
+	client := core.NewOptions(mock, []core.Option{core.WithTracer(&tracer), core.WithMetrics(&metrics)}).Client()
+	ctx, op := core.StartOperation(
+		context.Background(),
+		&tracer,
+		"routingv8",
+		"Routes",
+		telemetry.String(telemetry.AttributeTransportMode, "truck"),
+	)
+	u, err := url.Parse("https://router.hereapi.com/v8/routes")
+	assert.NilError(t, err)
+	req, err := core.NewRequest(ctx, "", u, http.MethodPost, "", []byte(`{}`))
+	assert.NilError(t, err)
+	err = core.Do(client, req, nil, core.DecodeJSON, func(*http.Response, []byte) error { return errors.New("error") })
+	assert.Error(t, err, "error")
+	op.End(err)
+	operationAttributes := []telemetry.Attribute{
+		telemetry.String(telemetry.AttributeAPI, "routingv8"),
+		telemetry.String(telemetry.AttributeTransportMode, "truck"),
+	}
+	assert.Equal(t, len(tracer.spans), 2)
+	operation := tracer.spans[0]
+	assert.Equal(t, operation.name, "Routes")
+	assert.Assert(t, operation.parent == nil)
+	assert.DeepEqual(t, operation.attributes, operationAttributes)
+	assert.DeepEqual(t, operation.errors, []error{err}, cmpopts.EquateErrors())
+	assert.Assert(t, operation.ended)
+	// The request is a child span of the operation, and is sent with the context of its span, so that a tracing
+	// transport can propagate it.
+	span := tracer.spans[1]
+	assert.Equal(t, mock.requests[0].Context().Value(spanKey{}), span)
+	assert.Equal(t, span.parent, operation)
+	assert.Equal(t, span.name, http.MethodPost)
+	method := telemetry.String(telemetry.AttributeHTTPMethod, http.MethodPost)
+	assert.DeepEqual(t, span.attributes, []telemetry.Attribute{
+		method,
+		telemetry.Int64(telemetry.AttributeRequestSize, 2),
+		telemetry.Int64(telemetry.AttributeResponseSize, 29),
+		telemetry.Int(telemetry.AttributeHTTPStatusCode, http.StatusTooManyRequests),
+	})
+	assert.Equal(t, len(span.errors), 1)
+	assert.Error(t, span.errors[0], "status code 429")
+	assert.Assert(t, span.ended)
+	assert.Equal(t, len(metrics.measurements), 1)
+	measurement := metrics.measurements[0]
+	assert.Assert(t, measurement.Duration > 0)
+	measurement.Duration = 0
+	assert.DeepEqual(t, measurement, telemetry.Measurement{
+		Operation: "Routes",
+		Attributes: append(
+			operationAttributes,
+			method,
+			telemetry.Int(telemetry.AttributeHTTPStatusCode, http.StatusTooManyRequests),
+		),
+		StatusCode:   http.StatusTooManyRequests,
+		RequestSize:  2,
+		ResponseSize: 29,
+	})
+	assert.Assert(t, measurement.Failed())
+}
+
+func TestOptions_Client_Telemetry_TransportError(t *testing.T) {
+	t.Parallel()
+	var tracer testTracer
+	var metrics testMetrics
+	transportErr := errors.New("connection refused")
+	client := core.NewOptions(
+		middlewareFunc(func(*http.Request) (*http.Response, error) { return nil, transportErr }),
+		[]core.Option{core.WithTracer(&tracer), core.WithMetrics(&metrics)},
+	).Client()
+	// Requests without an operation are named after their method.
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+	assert.NilError(t, err)
+	_, err = client.Do(req)
+	assert.Assert(t, errors.Is(err, transportErr))
+	assert.Equal(t, len(tracer.spans), 1)
+	assert.Equal(t, tracer.spans[0].name, http.MethodGet)
+	assert.DeepEqual(t, tracer.spans[0].errors, []error{transportErr}, cmpopts.EquateErrors())
+	assert.Assert(t, tracer.spans[0].ended)
+	assert.Equal(t, len(metrics.measurements), 1)
+	assert.Equal(t, metrics.measurements[0].StatusCode, 0)
+	assert.Assert(t, errors.Is(metrics.measurements[0].Err, transportErr))
+	assert.Assert(t, metrics.measurements[0].Failed())
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors classifying the errors of the HERE API clients, for use with errors.Is.
+//
+// Each client package re-exports them, and its response errors classify themselves with StatusError in their Is
+// method. Since the packages share the same values, e.g. errors.Is(err, routingv8.ErrRateLimited) also holds for a
+// rate limited geocodingsearchv7 request, so that callers using several clients can classify their errors in one
+// place.
+var (
+	// ErrUnauthorized classifies requests with missing or invalid credentials.
+	ErrUnauthorized = errors.New("here: unauthorized")
+	// ErrForbidden classifies requests the credentials are not allowed to make, e.g. to a service not in the plan.
+	ErrForbidden = errors.New("here: forbidden")
+	// ErrRateLimited classifies requests rejected because a rate limit or quota was exceeded.
+	ErrRateLimited = errors.New("here: rate limited")
+	// ErrBadRequest classifies requests rejected because of invalid input.
+	ErrBadRequest = errors.New("here: bad request")
+	// ErrNoRoute classifies requests for which no route could be found.
+	ErrNoRoute = errors.New("here: no route found")
+	// ErrServiceUnavailable classifies requests that failed because the service is temporarily unavailable.
+	ErrServiceUnavailable = errors.New("here: service unavailable")
+)
+
+// StatusError returns the sentinel error classifying an HTTP status code, or nil if the status code has none.
+func StatusError(statusCode int) error {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrServiceUnavailable
+	default:
+		return nil
+	}
+}
+
+// IsStatusError reports whether target is the sentinel error classifying an HTTP status code.
+func IsStatusError(statusCode int, target error) bool {
+	return target != nil && target == StatusError(statusCode)
+}
+
+// HereError holds the fields of the error body returned by the HERE APIs.
+// The HereErrorResponse type of each package can be converted to it.
+type HereError struct {
+	Title  string
+	Status int
+	Code   string
+	Cause  string
+	Action string
+}
+
+// ErrorMessage returns the message of a response error. The raw body is used when the body is not a HERE error.
+func ErrorMessage(response *HereError, body string, statusCode int) string {
+	if response == nil || response.Status == 0 {
+		return fmt.Sprintf("Response: %s StatusCode: %d", body, statusCode)
+	}
+	return fmt.Sprintf(
+		"Title: %v, Status: %d, Code: %v, Cause: %v, Action: %v",
+		response.Title,
+		response.Status,
+		response.Code,
+		response.Cause,
+		response.Action,
+	)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// NewTransport returns a RoundTripper which sends requests through the HTTPClient returned by middleware for next.
+// It lets middleware be used as the transport of an *http.Client, as accepted by routingv7.
+// If next is nil http.DefaultTransport is used.
+func NewTransport(next http.RoundTripper, middleware Middleware) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{client: middleware(roundTripperClient{next: next})}
+}
+
+type transport struct {
+	client HTTPClient
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.client.Do(req)
+}
+
+type roundTripperClient struct {
+	next http.RoundTripper
+}
+
+func (c roundTripperClient) Do(req *http.Request) (*http.Response, error) {
+	return c.next.RoundTrip(req)
+}
+
+// GetBody returns a function returning a fresh copy of the body of req, or nil if req has no body.
+// Bodies that can not be reproduced through req.GetBody are read into memory once, and req.Body and req.GetBody are
+// replaced with copies, so that req can still be sent.
+func GetBody(req *http.Request) (func() (io.ReadCloser, error), error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		return req.GetBody, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	if closeErr := req.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return req.GetBody, nil
+}
+
+// ReadBody returns the body of req without consuming it. See GetBody.
+func ReadBody(req *http.Request) ([]byte, error) {
+	getBody, err := GetBody(req)
+	if err != nil || getBody == nil {
+		return nil, err
+	}
+	body, err := getBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+type retriesKey struct{}
+
+// WithRetries returns a copy of ctx carrying the number of times a request was retried, for requests sent by a
+// retrying middleware.
+func WithRetries(ctx context.Context, retries int) context.Context {
+	return context.WithValue(ctx, retriesKey{}, retries)
+}
+
+// Retries returns the number of times the request of resp was retried before resp was received.
+// It returns 0 if resp was not received through a retrying middleware.
+func Retries(resp *http.Response) int {
+	if resp == nil || resp.Request == nil {
+		return 0
+	}
+	retries, _ := resp.Request.Context().Value(retriesKey{}).(int)
+	return retries
+}
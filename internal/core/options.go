@@ -0,0 +1,123 @@
+// Package core implements the transport, errors and request building shared by the HERE API clients.
+package core
+
+import (
+	"net/http"
+	"net/url"
+
+	"go.einride.tech/here/telemetry"
+)
+
+// DefaultUserAgent is the User-Agent header sent by the clients unless configured otherwise.
+const DefaultUserAgent = "einride/here-go"
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Middleware wraps an HTTPClient, e.g. to retry, rate limit or cache requests.
+type Middleware func(next HTTPClient) HTTPClient
+
+// Options configures a client.
+type Options struct {
+	// BaseURL replaces the scheme and host of every service URL of the client, and prefixes their paths.
+	BaseURL *url.URL
+	// UserAgent to send with every request.
+	UserAgent string
+	// HTTPClient to send requests with.
+	HTTPClient HTTPClient
+	// Middleware to wrap the HTTPClient with. The first middleware receives requests first.
+	Middleware []Middleware
+	// Tracer to start a span for every operation and request with.
+	Tracer telemetry.Tracer
+	// Metrics to record a measurement of every request with.
+	Metrics telemetry.Metrics
+}
+
+// Option configures a client.
+type Option func(*Options)
+
+// WithBaseURL sets the base URL of every service of the client, e.g. to point it to a proxy or a fake server.
+// The path of each service, e.g. /v8/ of the routing service, is kept and prefixed with the path of the base URL.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(o *Options) {
+		o.BaseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request. An empty user agent sends none.
+func WithUserAgent(userAgent string) Option {
+	return func(o *Options) {
+		o.UserAgent = userAgent
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, replacing the one passed to the constructor.
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return func(o *Options) {
+		o.HTTPClient = httpClient
+	}
+}
+
+// WithMiddleware wraps the HTTP client of the client with the given middleware, in order.
+// The first middleware receives requests first.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *Options) {
+		o.Middleware = append(o.Middleware, middleware...)
+	}
+}
+
+// WithTracer reports every service call of the client as a span, named after the operation, e.g. Routes. The
+// requests it sends are reported as child spans, named after their HTTP method.
+func WithTracer(tracer telemetry.Tracer) Option {
+	return func(o *Options) {
+		o.Tracer = tracer
+	}
+}
+
+// WithMetrics records a measurement of every request sent by the client, e.g. for latency histograms and error
+// counters.
+func WithMetrics(metrics telemetry.Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = metrics
+	}
+}
+
+// NewOptions returns the options of a client constructed with the given HTTP client and options.
+// If no HTTP client is provided, a new http.Client is used.
+func NewOptions(httpClient HTTPClient, opts []Option) *Options {
+	o := &Options{UserAgent: DefaultUserAgent, HTTPClient: httpClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{}
+	}
+	return o
+}
+
+// Client returns the HTTP client wrapped with the middleware. Requests are reported to the tracer and metrics, if
+// any, before the middleware, so that a span covers all retries of a request.
+func (o *Options) Client() HTTPClient {
+	client := o.HTTPClient
+	for i := len(o.Middleware) - 1; i >= 0; i-- {
+		client = o.Middleware[i](client)
+	}
+	if o.Tracer != nil || o.Metrics != nil {
+		client = &instrumentedClient{next: client, tracer: o.Tracer, metrics: o.Metrics}
+	}
+	return client
+}
+
+// ServiceURL returns the URL of a service, based on its default URL and the base URL.
+func (o *Options) ServiceURL(defaultURL string) *url.URL {
+	u, err := url.Parse(defaultURL)
+	if err != nil {
+		panic(err)
+	}
+	if o.BaseURL == nil {
+		return u
+	}
+	return o.BaseURL.JoinPath(u.Path)
+}
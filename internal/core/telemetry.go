@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.einride.tech/here/telemetry"
+)
+
+type operationKey struct{}
+
+// Operation is a service call in progress, e.g. the Routes operation of the routingv8 API. It is reported as a span,
+// with the spans of the requests it sends as children.
+type Operation struct {
+	api        string
+	name       string
+	attributes []telemetry.Attribute
+	span       telemetry.Span
+}
+
+// StartOperation starts an operation, reported as a span of tracer unless it is nil. The returned context carries
+// the operation, so that requests created with it are reported as its children, and operations started with it,
+// e.g. the tiles of a tiled matrix, as well. End the operation with the result of the service call.
+func StartOperation(
+	ctx context.Context,
+	tracer telemetry.Tracer,
+	api string,
+	name string,
+	attributes ...telemetry.Attribute,
+) (context.Context, *Operation) {
+	op := &Operation{api: api, name: name, attributes: attributes}
+	if tracer != nil {
+		spanAttributes := make([]telemetry.Attribute, 0, len(attributes)+1)
+		spanAttributes = append(spanAttributes, telemetry.String(telemetry.AttributeAPI, api))
+		spanAttributes = append(spanAttributes, attributes...)
+		ctx, op.span = tracer.Start(ctx, name, spanAttributes...)
+	}
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+// End ends the operation, and records err as its failure unless it is nil.
+func (op *Operation) End(err error) {
+	if op.span == nil {
+		return
+	}
+	if err != nil {
+		op.span.RecordError(err)
+	}
+	op.span.End()
+}
+
+// instrumentedClient reports the requests sent through next to a tracer and metrics.
+type instrumentedClient struct {
+	next    HTTPClient
+	tracer  telemetry.Tracer
+	metrics telemetry.Metrics
+}
+
+func (c *instrumentedClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	name := req.Method
+	attributes := make([]telemetry.Attribute, 0, 8)
+	if op, ok := ctx.Value(operationKey{}).(*Operation); ok {
+		name = op.name
+		attributes = append(attributes, telemetry.String(telemetry.AttributeAPI, op.api))
+		attributes = append(attributes, op.attributes...)
+	}
+	method := telemetry.String(telemetry.AttributeHTTPMethod, req.Method)
+	attributes = append(attributes, method)
+	var span telemetry.Span
+	if c.tracer != nil {
+		// The span of the request is named after its method, and is a child of the span of its operation, if any.
+		ctx, span = c.tracer.Start(ctx, req.Method, method)
+		req = req.WithContext(ctx)
+	}
+	r := &instrumentedRequest{
+		client: c,
+		ctx:    ctx,
+		span:   span,
+		measurement: telemetry.Measurement{
+			Operation:   name,
+			Attributes:  attributes,
+			RequestSize: max(req.ContentLength, 0),
+		},
+		start: time.Now(),
+	}
+	resp, err := c.next.Do(req)
+	if err != nil {
+		r.measurement.Err = err
+		r.finish()
+		return nil, err
+	}
+	r.measurement.StatusCode = resp.StatusCode
+	resp.Body = &instrumentedBody{ReadCloser: resp.Body, request: r}
+	return resp, nil
+}
+
+// instrumentedRequest is a request in progress, finished when its response body is closed.
+type instrumentedRequest struct {
+	client      *instrumentedClient
+	ctx         context.Context
+	span        telemetry.Span
+	measurement telemetry.Measurement
+	start       time.Time
+	once        sync.Once
+}
+
+func (r *instrumentedRequest) finish() {
+	r.once.Do(func() {
+		m := &r.measurement
+		m.Duration = time.Since(r.start)
+		responseAttributes := []telemetry.Attribute{
+			telemetry.Int64(telemetry.AttributeRequestSize, m.RequestSize),
+			telemetry.Int64(telemetry.AttributeResponseSize, m.ResponseSize),
+		}
+		if m.StatusCode != 0 {
+			statusCode := telemetry.Int(telemetry.AttributeHTTPStatusCode, m.StatusCode)
+			m.Attributes = append(m.Attributes, statusCode)
+			responseAttributes = append(responseAttributes, statusCode)
+		}
+		if r.span != nil {
+			r.span.SetAttributes(responseAttributes...)
+			switch {
+			case m.Err != nil:
+				r.span.RecordError(m.Err)
+			case m.Failed():
+				r.span.RecordError(fmt.Errorf("status code %d", m.StatusCode))
+			}
+			r.span.End()
+		}
+		if r.client.metrics != nil {
+			r.client.metrics.Record(r.ctx, *m)
+		}
+	})
+}
+
+// instrumentedBody counts the bytes read from a response body, and finishes its request when closed.
+type instrumentedBody struct {
+	io.ReadCloser
+	request *instrumentedRequest
+}
+
+func (b *instrumentedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.request.measurement.ResponseSize += int64(n)
+	return n, err
+}
+
+func (b *instrumentedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.request.finish()
+	return err
+}
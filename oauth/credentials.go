@@ -0,0 +1,149 @@
+// Package oauth provides HERE OAuth 2.0 token credentials for the HERE API clients.
+//
+// HERE platform credentials, as downloaded in a credentials.properties file, are exchanged for a bearer token
+// through a token request signed with OAuth 1.0a. The returned HTTP client sends the token in the Authorization
+// header of every request, so that no API key is put in query strings, and can be passed to routingv8, routingv7
+// and geocodingsearchv7.
+//
+// See https://www.here.com/docs/category/identity-and-access-management for details.
+package oauth
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultTokenEndpointURL is the token endpoint used when the credentials do not specify one.
+const DefaultTokenEndpointURL = "https://account.api.here.com/oauth2/token"
+
+// Environment variables read by CredentialsFromEnv.
+const (
+	EnvAccessKeyID      = "HERE_ACCESS_KEY_ID"
+	EnvAccessKeySecret  = "HERE_ACCESS_KEY_SECRET"
+	EnvTokenEndpointURL = "HERE_TOKEN_ENDPOINT_URL"
+	// EnvCredentialsFile is the path of a credentials.properties file, read by DefaultCredentials.
+	EnvCredentialsFile = "HERE_CREDENTIALS_FILE"
+)
+
+// Credentials are the HERE platform credentials of an app.
+type Credentials struct {
+	// UserID is the HERE user ID of the app, here.user.id in credentials.properties.
+	UserID string
+	// ClientID is the client ID of the app, here.client.id in credentials.properties.
+	ClientID string
+	// AccessKeyID is here.access.key.id in credentials.properties. Required.
+	AccessKeyID string
+	// AccessKeySecret is here.access.key.secret in credentials.properties. Required.
+	AccessKeySecret string
+	// TokenEndpointURL is here.token.endpoint.url in credentials.properties.
+	// If not specified defaults to DefaultTokenEndpointURL.
+	TokenEndpointURL string
+}
+
+func (c *Credentials) validate() error {
+	if c.AccessKeyID == "" {
+		return errors.New("missing access key ID")
+	}
+	if c.AccessKeySecret == "" {
+		return errors.New("missing access key secret")
+	}
+	return nil
+}
+
+func (c *Credentials) tokenEndpointURL() string {
+	if c.TokenEndpointURL != "" {
+		return c.TokenEndpointURL
+	}
+	return DefaultTokenEndpointURL
+}
+
+// ParseCredentials parses credentials in the format of a credentials.properties file.
+func ParseCredentials(r io.Reader) (_ *Credentials, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("parse credentials: %w", err)
+		}
+	}()
+	var credentials Credentials
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			key, value, ok = strings.Cut(line, ":")
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "here.user.id":
+			credentials.UserID = value
+		case "here.client.id":
+			credentials.ClientID = value
+		case "here.access.key.id":
+			credentials.AccessKeyID = value
+		case "here.access.key.secret":
+			credentials.AccessKeySecret = value
+		case "here.token.endpoint.url":
+			credentials.TokenEndpointURL = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := credentials.validate(); err != nil {
+		return nil, err
+	}
+	return &credentials, nil
+}
+
+// LoadCredentialsFile reads credentials from a credentials.properties file.
+func LoadCredentialsFile(name string) (*Credentials, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	defer f.Close()
+	return ParseCredentials(f)
+}
+
+// CredentialsFromEnv reads credentials from the HERE_ACCESS_KEY_ID, HERE_ACCESS_KEY_SECRET and optional
+// HERE_TOKEN_ENDPOINT_URL environment variables.
+func CredentialsFromEnv() (*Credentials, error) {
+	credentials := Credentials{
+		AccessKeyID:      os.Getenv(EnvAccessKeyID),
+		AccessKeySecret:  os.Getenv(EnvAccessKeySecret),
+		TokenEndpointURL: os.Getenv(EnvTokenEndpointURL),
+	}
+	if err := credentials.validate(); err != nil {
+		return nil, fmt.Errorf("credentials from env: %w", err)
+	}
+	return &credentials, nil
+}
+
+// DefaultCredentials looks for credentials in the following order:
+//   - the HERE_ACCESS_KEY_ID and HERE_ACCESS_KEY_SECRET environment variables,
+//   - the credentials.properties file named by the HERE_CREDENTIALS_FILE environment variable,
+//   - the file .here/credentials.properties in the home directory of the user.
+func DefaultCredentials() (*Credentials, error) {
+	if os.Getenv(EnvAccessKeyID) != "" || os.Getenv(EnvAccessKeySecret) != "" {
+		return CredentialsFromEnv()
+	}
+	if name := os.Getenv(EnvCredentialsFile); name != "" {
+		return LoadCredentialsFile(name)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("default credentials: %w", err)
+	}
+	return LoadCredentialsFile(filepath.Join(home, ".here", "credentials.properties"))
+}
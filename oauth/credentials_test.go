@@ -0,0 +1,84 @@
+package oauth_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.einride.tech/here/oauth"
+	"gotest.tools/v3/assert"
+)
+
+const credentialsProperties = `# HERE platform credentials
+here.user.id = HERE-5e2b2a3c-1b2f-4a1d-9c3e-0f8b7a6d5c4e
+here.client.id = fGh3jK4lM5nP6qR7sT8u
+here.access.key.id = AbCdEfGhIjKlMnOpQrStUv
+here.access.key.secret = aB1-cD2_eF3=gH4
+here.token.endpoint.url = https://account.api.here.com/oauth2/token
+`
+
+func TestParseCredentials(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name       string
+		properties string
+		expected   *oauth.Credentials
+		errStr     string
+	}{
+		{
+			name:       "complete",
+			properties: credentialsProperties,
+			expected: &oauth.Credentials{
+				UserID:           "HERE-5e2b2a3c-1b2f-4a1d-9c3e-0f8b7a6d5c4e",
+				ClientID:         "fGh3jK4lM5nP6qR7sT8u",
+				AccessKeyID:      "AbCdEfGhIjKlMnOpQrStUv",
+				AccessKeySecret:  "aB1-cD2_eF3=gH4",
+				TokenEndpointURL: "https://account.api.here.com/oauth2/token",
+			},
+		},
+		{
+			name:       "missing secret",
+			properties: "here.access.key.id=AbCdEfGhIjKlMnOpQrStUv\n",
+			errStr:     "parse credentials: missing access key secret",
+		},
+		{
+			name:       "invalid line",
+			properties: "here.access.key.id\n",
+			errStr:     `parse credentials: invalid line "here.access.key.id"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := oauth.ParseCredentials(strings.NewReader(tt.properties))
+			if tt.errStr != "" {
+				assert.Error(t, err, tt.errStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.expected)
+		})
+	}
+}
+
+func TestDefaultCredentials(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "credentials.properties")
+	assert.NilError(t, os.WriteFile(name, []byte(credentialsProperties), 0o600))
+	t.Setenv(oauth.EnvAccessKeyID, "")
+	t.Setenv(oauth.EnvAccessKeySecret, "")
+	t.Setenv(oauth.EnvCredentialsFile, name)
+	got, err := oauth.DefaultCredentials()
+	assert.NilError(t, err)
+	assert.Equal(t, got.AccessKeyID, "AbCdEfGhIjKlMnOpQrStUv")
+
+	t.Setenv(oauth.EnvAccessKeyID, "env-id")
+	t.Setenv(oauth.EnvAccessKeySecret, "env-secret")
+	got, err = oauth.DefaultCredentials()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, &oauth.Credentials{AccessKeyID: "env-id", AccessKeySecret: "env-secret"})
+
+	t.Setenv(oauth.EnvAccessKeySecret, "")
+	_, err = oauth.DefaultCredentials()
+	assert.Error(t, err, "credentials from env: missing access key secret")
+}
@@ -0,0 +1,234 @@
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.einride.tech/here/internal/core"
+)
+
+// DefaultRefreshBefore is how long before expiry a token is refreshed when TokenSourceConfig.RefreshBefore is not
+// specified.
+const DefaultRefreshBefore = 5 * time.Minute
+
+// Token is a HERE OAuth 2.0 bearer token.
+type Token struct {
+	// AccessToken to send in the Authorization header of requests.
+	AccessToken string
+	// TokenType is the type of the token, bearer.
+	TokenType string
+	// Expiry is the time at which the token expires.
+	Expiry time.Time
+}
+
+// TokenSourceConfig configures a TokenSource.
+type TokenSourceConfig struct {
+	// Credentials to exchange for tokens. Required.
+	Credentials *Credentials
+	// HTTPClient used to send token requests. If nil http.DefaultClient is used.
+	HTTPClient *http.Client
+	// RefreshBefore is how long before its expiry a token is replaced by a new one.
+	// If not specified defaults to DefaultRefreshBefore.
+	RefreshBefore time.Duration
+}
+
+// TokenSource requests tokens with signed token requests and caches them until shortly before they expire.
+// It is safe for concurrent use.
+type TokenSource struct {
+	config TokenSourceConfig
+	now    func() time.Time
+	nonce  func() (string, error)
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewTokenSource returns a TokenSource for the given config.
+func NewTokenSource(config TokenSourceConfig) (*TokenSource, error) {
+	if config.Credentials == nil {
+		return nil, fmt.Errorf("new token source: missing credentials")
+	}
+	if err := config.Credentials.validate(); err != nil {
+		return nil, fmt.Errorf("new token source: %w", err)
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.RefreshBefore <= 0 {
+		config.RefreshBefore = DefaultRefreshBefore
+	}
+	return &TokenSource{config: config, now: time.Now, nonce: randomNonce}, nil
+}
+
+// Token returns a valid token, requesting a new one if there is none or the cached one is about to expire.
+func (s *TokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.now().Before(s.token.Expiry.Add(-s.config.RefreshBefore)) {
+		return s.token, nil
+	}
+	token, err := s.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// tokenResponse is the body of a successful token response.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// TokenError is returned when the token endpoint rejects a token request.
+type TokenError struct {
+	// HTTPStatusCode of the token response.
+	HTTPStatusCode int
+	// HTTPBody of the token response.
+	HTTPBody string
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("token request failed: StatusCode: %d Response: %s", e.HTTPStatusCode, e.HTTPBody)
+}
+
+// Is reports whether the error is classified by target, one of the sentinel errors of the HERE API packages such as
+// ErrUnauthorized.
+func (e *TokenError) Is(target error) bool {
+	return core.IsStatusError(e.HTTPStatusCode, target)
+}
+
+func (s *TokenSource) requestToken(ctx context.Context) (_ *Token, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("request token: %w", err)
+		}
+	}()
+	endpoint := s.config.Credentials.tokenEndpointURL()
+	form := url.Values{"grant_type": []string{"client_credentials"}}
+	now := s.now()
+	authorization, err := s.authorization(endpoint, form, now)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", authorization)
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &TokenError{HTTPStatusCode: resp.StatusCode, HTTPBody: string(body)}
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("missing access token in response")
+	}
+	return &Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      now.Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// authorization returns the OAuth 1.0a Authorization header of a token request, signed with HMAC-SHA256.
+func (s *TokenSource) authorization(endpoint string, form url.Values, now time.Time) (string, error) {
+	nonce, err := s.nonce()
+	if err != nil {
+		return "", err
+	}
+	params := map[string]string{
+		"oauth_consumer_key":     s.config.Credentials.AccessKeyID,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": "HMAC-SHA256",
+		"oauth_timestamp":        strconv.FormatInt(now.Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	params["oauth_signature"] = signature(
+		http.MethodPost, endpoint, params, form, s.config.Credentials.AccessKeySecret,
+	)
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", percentEncode(key), percentEncode(params[key])))
+	}
+	return "OAuth " + strings.Join(parts, ","), nil
+}
+
+// signature returns the OAuth 1.0a HMAC-SHA256 signature of a request.
+// See https://datatracker.ietf.org/doc/html/rfc5849#section-3.4 for details.
+func signature(method, endpoint string, oauthParams map[string]string, form url.Values, secret string) string {
+	pairs := make([]string, 0, len(oauthParams)+len(form))
+	for key, value := range oauthParams {
+		pairs = append(pairs, percentEncode(key)+"="+percentEncode(value))
+	}
+	for key, values := range form {
+		for _, value := range values {
+			pairs = append(pairs, percentEncode(key)+"="+percentEncode(value))
+		}
+	}
+	sort.Strings(pairs)
+	base := strings.Join([]string{
+		method,
+		percentEncode(endpoint),
+		percentEncode(strings.Join(pairs, "&")),
+	}, "&")
+	mac := hmac.New(sha256.New, []byte(percentEncode(secret)+"&"))
+	_, _ = mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// percentEncode encodes s as specified by https://datatracker.ietf.org/doc/html/rfc5849#section-3.6.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func randomNonce() (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce), nil
+}
@@ -0,0 +1,134 @@
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSignature(t *testing.T) {
+	t.Parallel()
+	got := signature(
+		http.MethodPost,
+		"https://account.api.here.com/oauth2/token",
+		map[string]string{
+			"oauth_consumer_key":     "AbCdEfGhIjKlMnOpQrStUv",
+			"oauth_nonce":            "0123456789abcdef",
+			"oauth_signature_method": "HMAC-SHA256",
+			"oauth_timestamp":        "1704182400",
+			"oauth_version":          "1.0",
+		},
+		map[string][]string{"grant_type": {"client_credentials"}},
+		"aB1-cD2_eF3=gH4",
+	)
+	assert.Equal(t, got, expectedSignature(t))
+}
+
+// expectedSignature signs the signature base string of TestSignature, written out by hand following
+// https://datatracker.ietf.org/doc/html/rfc5849#section-3.4.1.
+func expectedSignature(t *testing.T) string {
+	t.Helper()
+	base := "POST&https%3A%2F%2Faccount.api.here.com%2Foauth2%2Ftoken&" +
+		"grant_type%3Dclient_credentials%26oauth_consumer_key%3DAbCdEfGhIjKlMnOpQrStUv%26" +
+		"oauth_nonce%3D0123456789abcdef%26oauth_signature_method%3DHMAC-SHA256%26" +
+		"oauth_timestamp%3D1704182400%26oauth_version%3D1.0"
+	mac := hmac.New(sha256.New, []byte("aB1-cD2_eF3%3DgH4&"))
+	_, _ = mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		assert.Check(t, r.Method == http.MethodPost)
+		assert.Check(t, r.ParseForm())
+		assert.Check(t, r.PostForm.Get("grant_type") == "client_credentials")
+		authorization := r.Header.Get("Authorization")
+		assert.Check(t, strings.HasPrefix(authorization, "OAuth "))
+		assert.Check(t, strings.Contains(authorization, `oauth_consumer_key="id"`))
+		assert.Check(t, strings.Contains(authorization, `oauth_signature_method="HMAC-SHA256"`))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`, requests)
+	}))
+	defer server.Close()
+	source, err := NewTokenSource(TokenSourceConfig{
+		Credentials: &Credentials{AccessKeyID: "id", AccessKeySecret: "secret", TokenEndpointURL: server.URL},
+	})
+	assert.NilError(t, err)
+	now := time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)
+	source.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	token, err := source.Token(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, token, &Token{AccessToken: "token-1", TokenType: "bearer", Expiry: now.Add(time.Hour)})
+	// The token is cached until shortly before it expires.
+	now = now.Add(54 * time.Minute)
+	token, err = source.Token(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, token.AccessToken, "token-1")
+	now = now.Add(time.Minute)
+	token, err = source.Token(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, token.AccessToken, "token-2")
+}
+
+func TestTokenSource_Error(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errorCode":401300,"message":"Signature mismatch"}`))
+	}))
+	defer server.Close()
+	source, err := NewTokenSource(TokenSourceConfig{
+		Credentials: &Credentials{AccessKeyID: "id", AccessKeySecret: "secret", TokenEndpointURL: server.URL},
+	})
+	assert.NilError(t, err)
+	_, err = source.Token(context.Background())
+	assert.Error(
+		t,
+		err,
+		`request token: token request failed: StatusCode: 401 Response: {"errorCode":401300,"message":"Signature mismatch"}`,
+	)
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Check(t, r.Header.Get("Authorization") == "Bearer token")
+		assert.Check(t, r.URL.Query().Get("apiKey") == "")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer apiServer.Close()
+	source, err := NewTokenSource(TokenSourceConfig{
+		Credentials: &Credentials{AccessKeyID: "id", AccessKeySecret: "secret", TokenEndpointURL: tokenServer.URL},
+	})
+	assert.NilError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, apiServer.URL+"/v8/routes", nil)
+	assert.NilError(t, err)
+	resp, err := NewHTTPClient(source, nil).Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusNoContent)
+	// The request of the caller is not modified.
+	assert.Equal(t, req.Header.Get("Authorization"), "")
+}
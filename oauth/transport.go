@@ -0,0 +1,37 @@
+package oauth
+
+import (
+	"net/http"
+)
+
+type bearerRoundTripper struct {
+	source *TokenSource
+	next   http.RoundTripper
+}
+
+// NewHTTPClient returns an HTTP Client which authenticates requests with tokens from the given source.
+// If next is nil http.DefaultTransport is used.
+func NewHTTPClient(source *TokenSource, next http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: &bearerRoundTripper{
+			source: source,
+			next:   next,
+		},
+	}
+}
+
+func (r *bearerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := r.source.Token(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	if r.next != nil {
+		return r.next.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
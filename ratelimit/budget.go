@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+
+	"go.einride.tech/here/internal/core"
+)
+
+// QuotaExceededError is returned when a request would exceed the quota of its endpoint.
+type QuotaExceededError struct {
+	// Endpoint whose quota would be exceeded.
+	Endpoint Endpoint
+	// Quota of the endpoint, in billable units.
+	Quota int64
+	// Used units of the endpoint before the request.
+	Used int64
+	// Requested units of the request.
+	Requested int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"ratelimit: quota of endpoint %s exceeded: requested %d units with %d of %d used",
+		e.Endpoint,
+		e.Requested,
+		e.Used,
+		e.Quota,
+	)
+}
+
+// Is reports whether target is the ErrRateLimited sentinel error of the HERE API packages.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == core.ErrRateLimited
+}
+
+// Budget tracks the billable units used per endpoint against a quota. It is safe for concurrent use.
+//
+// Usage is counted from the creation of the Budget, or from the last call to Reset. Call Reset at the start of
+// every billing period, and SetUsed to resume from usage recorded elsewhere.
+type Budget struct {
+	mu     sync.Mutex
+	quotas map[Endpoint]int64
+	used   map[Endpoint]int64
+}
+
+// NewBudget returns a Budget enforcing the given quotas, in billable units. Usage of endpoints without a quota is
+// counted but never exceeds the budget.
+func NewBudget(quotas map[Endpoint]int64) *Budget {
+	b := &Budget{quotas: make(map[Endpoint]int64, len(quotas)), used: make(map[Endpoint]int64)}
+	for endpoint, quota := range quotas {
+		b.quotas[endpoint] = quota
+	}
+	return b
+}
+
+// Reserve counts units as used by the endpoint, or returns a *QuotaExceededError if that would exceed its quota.
+func (b *Budget) Reserve(endpoint Endpoint, units int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	used := b.used[endpoint]
+	if quota, ok := b.quotas[endpoint]; ok && used+units > quota {
+		return &QuotaExceededError{Endpoint: endpoint, Quota: quota, Used: used, Requested: units}
+	}
+	b.used[endpoint] = used + units
+	return nil
+}
+
+// Release gives back units which were reserved but not used by the endpoint.
+func (b *Budget) Release(endpoint Endpoint, units int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used[endpoint] = max(b.used[endpoint]-units, 0)
+}
+
+// Used returns the units used by the endpoint.
+func (b *Budget) Used(endpoint Endpoint) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used[endpoint]
+}
+
+// Remaining returns the units left in the quota of the endpoint, and false if the endpoint has no quota.
+func (b *Budget) Remaining(endpoint Endpoint) (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	quota, ok := b.quotas[endpoint]
+	if !ok {
+		return 0, false
+	}
+	return max(quota-b.used[endpoint], 0), true
+}
+
+// SetUsed sets the units used by the endpoint, e.g. to resume from usage recorded by a previous process.
+func (b *Budget) SetUsed(endpoint Endpoint, units int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used[endpoint] = units
+}
+
+// Reset sets the units used by every endpoint to zero, e.g. at the start of a billing period.
+func (b *Budget) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used = make(map[Endpoint]int64)
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limit is the allowed rate of requests to an endpoint.
+type Limit struct {
+	// RequestsPerSecond is the sustained rate of requests.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be sent at once after a period of inactivity.
+	// If not specified defaults to 1.
+	Burst int
+}
+
+// Limiter limits the rate of requests per endpoint. It is safe for concurrent use.
+type Limiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[Endpoint]*bucket
+}
+
+// NewLimiter returns a Limiter enforcing the given limits. Requests to endpoints without a limit are not limited.
+func NewLimiter(limits map[Endpoint]Limit) *Limiter {
+	l := &Limiter{now: time.Now, buckets: make(map[Endpoint]*bucket, len(limits))}
+	for endpoint, limit := range limits {
+		burst := float64(max(limit.Burst, 1))
+		l.buckets[endpoint] = &bucket{rate: limit.RequestsPerSecond, burst: burst, tokens: burst}
+	}
+	return l
+}
+
+// Wait blocks until a request can be sent to the endpoint, or until ctx is done.
+func (l *Limiter) Wait(ctx context.Context, endpoint Endpoint) error {
+	wait, ok := l.reserve(endpoint)
+	if !ok || wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel(endpoint)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket of the endpoint and returns how long to wait until it is available.
+func (l *Limiter) reserve(endpoint Endpoint) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[endpoint]
+	if !ok || b.rate <= 0 {
+		return 0, false
+	}
+	b.refill(l.now())
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second)), true
+}
+
+// cancel gives back a token which was reserved but not used.
+func (l *Limiter) cancel(endpoint Endpoint) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[endpoint]; ok {
+		b.tokens = min(b.tokens+1, b.burst)
+	}
+}
+
+// bucket is a token bucket. The number of tokens is negative when requests are waiting for tokens.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, b.burst)
+	}
+	b.last = now
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLimiter_reserve(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(map[Endpoint]Limit{
+		EndpointRouter: {RequestsPerSecond: 2, Burst: 2},
+	})
+	limiter.now = func() time.Time { return now }
+	for _, expected := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		wait, ok := limiter.reserve(EndpointRouter)
+		assert.Assert(t, ok)
+		assert.Equal(t, wait, expected)
+	}
+	// Refill the two waiting requests and a full burst.
+	now = now.Add(2 * time.Second)
+	for _, expected := range []time.Duration{0, 0, 500 * time.Millisecond} {
+		wait, _ := limiter.reserve(EndpointRouter)
+		assert.Equal(t, wait, expected)
+	}
+	_, ok := limiter.reserve(EndpointGeocode)
+	assert.Assert(t, !ok)
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Parallel()
+	limiter := NewLimiter(map[Endpoint]Limit{
+		EndpointGeocode: {RequestsPerSecond: 0.001},
+	})
+	ctx := context.Background()
+	assert.NilError(t, limiter.Wait(ctx, EndpointGeocode))
+	assert.NilError(t, limiter.Wait(ctx, EndpointRevGeocode))
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, EndpointGeocode), context.DeadlineExceeded)
+	// The canceled request gives back its token.
+	assert.Assert(t, limiter.buckets[EndpointGeocode].tokens > -1)
+}
@@ -0,0 +1,242 @@
+// Package ratelimit provides client-side rate limiting and quota budgeting for the HERE API clients.
+//
+// The rate limiting middleware plugs into the WithMiddleware option of the HERE API clients, and the rate limiting
+// transport can be used with the *http.Client accepted by routingv7. A single Limiter and Budget can be shared by
+// all clients and goroutines of a process, so that every HERE service draws from the same limits.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"go.einride.tech/here/internal/core"
+)
+
+// Endpoint identifies a HERE service with its own rate limits and quota.
+type Endpoint string
+
+const (
+	// EndpointRouter is the routing service, e.g. routes and route import.
+	EndpointRouter Endpoint = "router"
+	// EndpointMatrix is the matrix routing service.
+	EndpointMatrix Endpoint = "matrix"
+	// EndpointGeocode is the geocoding service.
+	EndpointGeocode Endpoint = "geocode"
+	// EndpointRevGeocode is the reverse geocoding service.
+	EndpointRevGeocode Endpoint = "revgeocode"
+	// EndpointAutosuggest is the autosuggest service.
+	EndpointAutosuggest Endpoint = "autosuggest"
+	// EndpointDiscover is the discover place search service.
+	EndpointDiscover Endpoint = "discover"
+	// EndpointBrowse is the browse place search service.
+	EndpointBrowse Endpoint = "browse"
+	// EndpointLookup is the lookup service.
+	EndpointLookup Endpoint = "lookup"
+	// EndpointBatch is the batch geocoding service.
+	EndpointBatch Endpoint = "batch"
+)
+
+// HTTPClient is the interface used by the HERE API clients to send requests.
+type HTTPClient = core.HTTPClient
+
+// Middleware wraps the HTTPClient of a HERE API client, as accepted by its WithMiddleware option.
+type Middleware = core.Middleware
+
+// Config configures the rate limiting and budgeting of requests.
+type Config struct {
+	// Limiter to wait for before sending requests. If nil requests are not rate limited.
+	Limiter *Limiter
+	// Budget to reserve the billable units of requests from. If nil requests are not budgeted.
+	Budget *Budget
+	// Classify returns the endpoint of a request, or an empty endpoint if the request should not be limited.
+	// If not specified defaults to ClassifyRequest.
+	Classify func(req *http.Request) Endpoint
+	// Units returns the number of billable units of a request to the given endpoint.
+	// If not specified defaults to RequestUnits.
+	Units func(req *http.Request, endpoint Endpoint) (int64, error)
+}
+
+// Client is an HTTPClient that rate limits and budgets requests.
+type Client struct {
+	next   HTTPClient
+	config Config
+}
+
+// NewHTTPClient returns an HTTPClient which limits the requests sent through next according to config.
+// If next is nil http.DefaultClient is used.
+func NewHTTPClient(next HTTPClient, config Config) *Client {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	if config.Classify == nil {
+		config.Classify = ClassifyRequest
+	}
+	if config.Units == nil {
+		config.Units = RequestUnits
+	}
+	return &Client{next: next, config: config}
+}
+
+// NewMiddleware returns a Middleware which limits requests according to config.
+func NewMiddleware(config Config) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return NewHTTPClient(next, config)
+	}
+}
+
+// NewTransport returns a RoundTripper which limits the requests sent through next according to config.
+// If next is nil http.DefaultTransport is used.
+func NewTransport(next http.RoundTripper, config Config) http.RoundTripper {
+	return core.NewTransport(next, NewMiddleware(config))
+}
+
+// Do reserves the billable units of the request from the budget, waits for the rate limit of its endpoint and then
+// sends it. A *QuotaExceededError is returned without sending the request if the budget would be exceeded.
+// The reserved units are given back if the request is not sent, fails with a network error or is rejected with
+// 429 Too Many Requests.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	endpoint := c.config.Classify(req)
+	if endpoint == "" {
+		return c.next.Do(req)
+	}
+	var units int64
+	if c.config.Budget != nil {
+		var err error
+		if units, err = c.config.Units(req, endpoint); err != nil {
+			return nil, fmt.Errorf("ratelimit: count units: %w", err)
+		}
+		if err := c.config.Budget.Reserve(endpoint, units); err != nil {
+			return nil, err
+		}
+	}
+	if c.config.Limiter != nil {
+		if err := c.config.Limiter.Wait(req.Context(), endpoint); err != nil {
+			c.release(endpoint, units)
+			return nil, err
+		}
+	}
+	resp, err := c.next.Do(req)
+	if err != nil || resp.StatusCode == http.StatusTooManyRequests {
+		c.release(endpoint, units)
+	}
+	return resp, err
+}
+
+func (c *Client) release(endpoint Endpoint, units int64) {
+	if c.config.Budget != nil {
+		c.config.Budget.Release(endpoint, units)
+	}
+}
+
+// ClassifyRequest returns the endpoint of a request to one of the HERE APIs supported by this module, based on the
+// path of its URL. The path may have a prefix, e.g. when the clients are pointed to a proxy with WithBaseURL.
+// An empty endpoint is returned for unknown requests.
+func ClassifyRequest(req *http.Request) Endpoint {
+	p := req.URL.Path
+	base := path.Base(p)
+	switch {
+	case hasSegments(p, "v8", "matrix"), base == "calculatematrix.json":
+		return EndpointMatrix
+	case hasSegments(p, "v8"), base == "calculateroute.json", base == "getroute.json":
+		return EndpointRouter
+	case base == "geocode":
+		return EndpointGeocode
+	case base == "revgeocode":
+		return EndpointRevGeocode
+	case base == "autosuggest":
+		return EndpointAutosuggest
+	case base == "discover":
+		return EndpointDiscover
+	case base == "browse":
+		return EndpointBrowse
+	case base == "lookup":
+		return EndpointLookup
+	case hasSegments(p, "6.2", "jobs"):
+		return EndpointBatch
+	default:
+		return ""
+	}
+}
+
+// hasSegments reports whether the path has the consecutive segments, e.g. "v8" and "matrix" in /here/v8/matrix/id.
+func hasSegments(p string, segments ...string) bool {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	for i := 0; i+len(segments) <= len(parts); i++ {
+		if slices.Equal(parts[i:i+len(segments)], segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestUnits returns the number of billable units of a request to the given endpoint:
+//   - one unit per cell, i.e. origins times destinations, for requests calculating a matrix,
+//   - one unit per record for requests starting a batch geocoding job,
+//   - no units for requests polling the status or downloading the result of a matrix or batch job,
+//   - one unit for other requests.
+func RequestUnits(req *http.Request, endpoint Endpoint) (int64, error) {
+	switch endpoint {
+	case EndpointMatrix:
+		if req.Method == http.MethodGet && path.Base(req.URL.Path) == "calculatematrix.json" {
+			return matrixV7Cells(req), nil
+		}
+		if req.Method != http.MethodPost {
+			return 0, nil
+		}
+		body, err := core.ReadBody(req)
+		if err != nil {
+			return 0, err
+		}
+		return matrixV8Cells(body)
+	case EndpointBatch:
+		if req.Method != http.MethodPost {
+			return 0, nil
+		}
+		body, err := core.ReadBody(req)
+		if err != nil {
+			return 0, err
+		}
+		// The first line of the body holds the column names.
+		return int64(bytes.Count(bytes.TrimSpace(body), []byte("\n"))), nil
+	default:
+		return 1, nil
+	}
+}
+
+// MatrixCells returns the number of billable units of a matrix with the given number of origins and destinations.
+func MatrixCells(numOrigins, numDestinations int) int64 {
+	return int64(numOrigins) * int64(numDestinations)
+}
+
+func matrixV7Cells(req *http.Request) int64 {
+	var starts, destinations int
+	for key := range req.URL.Query() {
+		switch {
+		case strings.HasPrefix(key, "start"):
+			starts++
+		case strings.HasPrefix(key, "destination"):
+			destinations++
+		}
+	}
+	return MatrixCells(starts, destinations)
+}
+
+func matrixV8Cells(body []byte) (int64, error) {
+	var matrix struct {
+		Origins      []json.RawMessage `json:"origins"`
+		Destinations []json.RawMessage `json:"destinations"`
+	}
+	if err := json.Unmarshal(body, &matrix); err != nil {
+		return 0, err
+	}
+	// Without destinations, the matrix is calculated from every origin to every other origin.
+	if matrix.Destinations == nil {
+		return MatrixCells(len(matrix.Origins), len(matrix.Origins)), nil
+	}
+	return MatrixCells(len(matrix.Origins), len(matrix.Destinations)), nil
+}
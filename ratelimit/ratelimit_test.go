@@ -0,0 +1,238 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/ratelimit"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+type CountingMock struct {
+	mu         sync.Mutex
+	requests   int
+	statusCode int
+	body       string
+}
+
+func (c *CountingMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	statusCode := c.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestClassifyRequest(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		url      string
+		expected ratelimit.Endpoint
+	}{
+		{url: "https://router.hereapi.com/v8/routes", expected: ratelimit.EndpointRouter},
+		{url: "https://router.hereapi.com/v8/import", expected: ratelimit.EndpointRouter},
+		{url: "https://route.ls.hereapi.com/routing/7.2/calculateroute.json", expected: ratelimit.EndpointRouter},
+		{url: "https://matrix.router.hereapi.com/v8/matrix", expected: ratelimit.EndpointMatrix},
+		{url: "https://matrix.router.hereapi.com/v8/matrix/id/status", expected: ratelimit.EndpointMatrix},
+		{
+			url:      "https://matrix.route.ls.hereapi.com/routing/7.2/calculatematrix.json",
+			expected: ratelimit.EndpointMatrix,
+		},
+		{url: "https://geocode.search.hereapi.com/v1/geocode", expected: ratelimit.EndpointGeocode},
+		{url: "https://revgeocode.search.hereapi.com/v1/revgeocode", expected: ratelimit.EndpointRevGeocode},
+		{url: "https://autosuggest.search.hereapi.com/v1/autosuggest", expected: ratelimit.EndpointAutosuggest},
+		{url: "https://discover.search.hereapi.com/v1/discover", expected: ratelimit.EndpointDiscover},
+		{url: "https://browse.search.hereapi.com/v1/browse", expected: ratelimit.EndpointBrowse},
+		{url: "https://lookup.search.hereapi.com/v1/lookup", expected: ratelimit.EndpointLookup},
+		{url: "https://batch.geocoder.ls.hereapi.com/6.2/jobs", expected: ratelimit.EndpointBatch},
+		{url: "https://batch.geocoder.ls.hereapi.com/6.2/jobs/id/result", expected: ratelimit.EndpointBatch},
+		{url: "https://example.com/", expected: ""},
+		{url: "https://proxy.example.com/here/v8/routes", expected: ratelimit.EndpointRouter},
+		{url: "https://proxy.example.com/here/v8/matrix/id/status", expected: ratelimit.EndpointMatrix},
+		{url: "https://proxy.example.com/here/6.2/jobs/id/result", expected: ratelimit.EndpointBatch},
+		{url: "https://proxy.example.com/here/v1/geocode", expected: ratelimit.EndpointGeocode},
+		{url: "https://example.com/v8x/routes", expected: ""},
+	} {
+		tt := tt
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			assert.NilError(t, err)
+			assert.Equal(t, ratelimit.ClassifyRequest(req), tt.expected)
+		})
+	}
+}
+
+func TestRequestUnits(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		expected int64
+	}{
+		{
+			name:     "routes",
+			method:   http.MethodGet,
+			url:      "https://router.hereapi.com/v8/routes",
+			expected: 1,
+		},
+		{
+			name:     "matrix",
+			method:   http.MethodPost,
+			url:      "https://matrix.router.hereapi.com/v8/matrix",
+			body:     `{"origins":[{"lat":1,"lng":1},{"lat":2,"lng":2}],"destinations":[{"lat":3,"lng":3}]}`,
+			expected: 2,
+		},
+		{
+			name:     "matrix without destinations",
+			method:   http.MethodPost,
+			url:      "https://matrix.router.hereapi.com/v8/matrix",
+			body:     `{"origins":[{"lat":1,"lng":1},{"lat":2,"lng":2},{"lat":3,"lng":3}]}`,
+			expected: 9,
+		},
+		{
+			name:     "matrix status",
+			method:   http.MethodGet,
+			url:      "https://matrix.router.hereapi.com/v8/matrix/id/status",
+			expected: 0,
+		},
+		{
+			name:   "v7 matrix",
+			method: http.MethodGet,
+			url: "https://matrix.route.ls.hereapi.com/routing/7.2/calculatematrix.json" +
+				"?start0=geo!1,1&start1=geo!2,2&destination0=geo!3,3&destination1=geo!4,4&destination2=geo!5,5",
+			expected: 6,
+		},
+		{
+			name:     "batch",
+			method:   http.MethodPost,
+			url:      "https://batch.geocoder.ls.hereapi.com/6.2/jobs",
+			body:     "recId|searchText|country\n1|Regeringsgatan 65|SWE\n2|Lindholmspiren 3|SWE",
+			expected: 2,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, tt.url, body)
+			assert.NilError(t, err)
+			units, err := ratelimit.RequestUnits(req, ratelimit.ClassifyRequest(req))
+			assert.NilError(t, err)
+			assert.Equal(t, units, tt.expected)
+			// The body is still available to send.
+			if tt.body != "" {
+				got, err := io.ReadAll(req.Body)
+				assert.NilError(t, err)
+				assert.Equal(t, string(got), tt.body)
+			}
+		})
+	}
+}
+
+func TestClient_Budget(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	mock := CountingMock{body: `{"matrixId":"id","matrix":{"numOrigins":2,"numDestinations":3}}`}
+	budget := ratelimit.NewBudget(map[ratelimit.Endpoint]int64{ratelimit.EndpointMatrix: 10})
+	client := routingv8.NewClient(ratelimit.NewHTTPClient(&mock, ratelimit.Config{Budget: budget}))
+	request := &routingv8.CalculateMatrixRequest{
+		Body: &routingv8.CalculateMatrixBody{
+			Origins:      []*routingv8.GeoWaypoint{{Lat: 1, Long: 1}, {Lat: 2, Long: 2}},
+			Destinations: []*routingv8.GeoWaypoint{{Lat: 3, Long: 3}, {Lat: 4, Long: 4}, {Lat: 5, Long: 5}},
+			RegionDefinition: routingv8.RegionDefinition{
+				Type: routingv8.RegionTypeWorld,
+			},
+		},
+	}
+	_, err := client.Matrix.CalculateMatrix(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, budget.Used(ratelimit.EndpointMatrix), int64(6))
+	_, err = client.Matrix.CalculateMatrix(ctx, request)
+	assert.ErrorContains(t, err, "ratelimit: quota of endpoint matrix exceeded: requested 6 units with 6 of 10 used")
+	// The request exceeding the quota is never sent.
+	assert.Equal(t, mock.requests, 1)
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		"https://matrix.router.hereapi.com/v8/matrix",
+		strings.NewReader(`{"origins":[{"lat":1,"lng":1},{"lat":2,"lng":2},{"lat":3,"lng":3}]}`),
+	)
+	assert.NilError(t, err)
+	_, err = ratelimit.NewHTTPClient(&mock, ratelimit.Config{Budget: budget}).Do(req)
+	var quotaErr *ratelimit.QuotaExceededError
+	assert.Assert(t, errors.As(err, &quotaErr))
+	assert.DeepEqual(t, *quotaErr, ratelimit.QuotaExceededError{
+		Endpoint:  ratelimit.EndpointMatrix,
+		Quota:     10,
+		Used:      6,
+		Requested: 9,
+	})
+	remaining, ok := budget.Remaining(ratelimit.EndpointMatrix)
+	assert.Assert(t, ok)
+	assert.Equal(t, remaining, int64(4))
+}
+
+func TestClient_Budget_ReleasedOnTooManyRequests(t *testing.T) {
+	t.Parallel()
+	mock := CountingMock{
+		statusCode: http.StatusTooManyRequests,
+		body:       `{"title":"Too Many Requests","status":429}`,
+	}
+	budget := ratelimit.NewBudget(map[ratelimit.Endpoint]int64{ratelimit.EndpointGeocode: 1})
+	client := geocodingsearchv7.NewClient(
+		&mock, geocodingsearchv7.WithMiddleware(ratelimit.NewMiddleware(ratelimit.Config{Budget: budget})),
+	)
+	q := "Regeringsgatan 65, Stockholm"
+	for i := 0; i < 2; i++ {
+		_, err := client.Geocoding.Geocoding(context.Background(), &geocodingsearchv7.GeocodingRequest{Q: &q})
+		var responseErr *geocodingsearchv7.ResponseError
+		assert.Assert(t, errors.As(err, &responseErr))
+	}
+	assert.Equal(t, mock.requests, 2)
+	assert.Equal(t, budget.Used(ratelimit.EndpointGeocode), int64(0))
+}
+
+func TestClient_SharedLimiter(t *testing.T) {
+	t.Parallel()
+	mock := CountingMock{}
+	limiter := ratelimit.NewLimiter(map[ratelimit.Endpoint]ratelimit.Limit{
+		ratelimit.EndpointRouter: {RequestsPerSecond: 1000, Burst: 5},
+	})
+	client := ratelimit.NewHTTPClient(&mock, ratelimit.Config{Limiter: limiter})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodGet, "https://router.hereapi.com/v8/routes", nil,
+			)
+			assert.Check(t, err)
+			resp, err := client.Do(req)
+			assert.Check(t, err)
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, mock.requests, 20)
+}
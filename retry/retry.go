@@ -0,0 +1,238 @@
+// Package retry provides an opt-in retry layer for the HERE API clients.
+//
+// The retrying middleware plugs into the WithMiddleware option of the HERE API clients, and the retrying transport
+// can be used with the *http.Client accepted by routingv7. Failed requests are retried with exponential backoff and
+// full jitter, and the Retry-After header of the response is honored when present.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.einride.tech/here/internal/core"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when Config.MaxRetries is not specified.
+	DefaultMaxRetries = 3
+	// DefaultInitialBackoff is the backoff used when Config.InitialBackoff is not specified.
+	DefaultInitialBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff is the backoff used when Config.MaxBackoff is not specified.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// HTTPClient is the interface used by the HERE API clients to send requests.
+type HTTPClient = core.HTTPClient
+
+// Middleware wraps the HTTPClient of a HERE API client, as accepted by its WithMiddleware option.
+type Middleware = core.Middleware
+
+// Config configures when and how requests are retried.
+type Config struct {
+	// MaxRetries is the maximum number of retries of a request, not counting the first attempt.
+	// If not specified defaults to DefaultMaxRetries.
+	MaxRetries int
+	// InitialBackoff is the upper bound of the backoff before the first retry. The upper bound doubles for every
+	// retry, and the actual backoff is chosen at random below it.
+	// If not specified defaults to DefaultInitialBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum backoff between two attempts.
+	// A response with a Retry-After header asking for a longer wait is returned without being retried.
+	// If not specified defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+	// IsIdempotent reports whether a request can safely be sent again after it may have been processed by the API,
+	// i.e. after a network error or a 500, 502 or 504 response.
+	// If not specified, GET, HEAD, OPTIONS, PUT and DELETE requests, and requests with an Idempotency-Key or
+	// X-Idempotency-Key header, are considered idempotent.
+	// Non-idempotent requests, such as POST requests starting an asynchronous matrix calculation, are only retried
+	// on 429 and 503 responses, which are sent when the request was rejected without being processed.
+	IsIdempotent func(req *http.Request) bool
+}
+
+func (c *Config) maxRetries() int {
+	if c.MaxRetries > 0 {
+		return c.MaxRetries
+	}
+	return DefaultMaxRetries
+}
+
+func (c *Config) initialBackoff() time.Duration {
+	if c.InitialBackoff > 0 {
+		return c.InitialBackoff
+	}
+	return DefaultInitialBackoff
+}
+
+func (c *Config) maxBackoff() time.Duration {
+	if c.MaxBackoff > 0 {
+		return c.MaxBackoff
+	}
+	return DefaultMaxBackoff
+}
+
+func (c *Config) isIdempotent(req *http.Request) bool {
+	if c.IsIdempotent != nil {
+		return c.IsIdempotent(req)
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	_, hasKey := req.Header["Idempotency-Key"]
+	_, hasXKey := req.Header["X-Idempotency-Key"]
+	return hasKey || hasXKey
+}
+
+// Client is an HTTPClient that retries failed requests.
+type Client struct {
+	next   HTTPClient
+	config Config
+}
+
+// NewHTTPClient returns an HTTPClient which retries requests sent through next according to config.
+// If next is nil http.DefaultClient is used.
+func NewHTTPClient(next HTTPClient, config Config) *Client {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	return &Client{next: next, config: config}
+}
+
+// NewMiddleware returns a Middleware which retries requests according to config.
+func NewMiddleware(config Config) Middleware {
+	return func(next HTTPClient) HTTPClient {
+		return NewHTTPClient(next, config)
+	}
+}
+
+// NewTransport returns a RoundTripper which retries requests sent through next according to config.
+// If next is nil http.DefaultTransport is used.
+func NewTransport(next http.RoundTripper, config Config) http.RoundTripper {
+	return core.NewTransport(next, NewMiddleware(config))
+}
+
+// Retries returns the number of times the request of resp was retried before resp was received.
+// It returns 0 if resp was not received through a retrying Client.
+func Retries(resp *http.Response) int {
+	return core.Retries(resp)
+}
+
+// Do sends the request, retrying it when it fails with a network error or a retryable status code.
+// The last response is returned once the request succeeds, fails with a non-retryable status code or runs out of
+// retries. The number of retries performed is available through Retries.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	getBody, err := core.GetBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("retry: read request body: %w", err)
+	}
+	ctx := req.Context()
+	idempotent := c.config.isIdempotent(req)
+	for retries := 0; ; retries++ {
+		attempt := req.Clone(core.WithRetries(ctx, retries))
+		if getBody != nil {
+			if attempt.Body, err = getBody(); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := c.next.Do(attempt)
+		wait, retry := c.backoff(retries), false
+		if err != nil {
+			retry = idempotent && ctx.Err() == nil
+		} else {
+			wait, retry = c.shouldRetry(resp, idempotent, wait)
+		}
+		if !retry || retries >= c.config.maxRetries() {
+			if err != nil {
+				if retries > 0 {
+					return nil, fmt.Errorf("retry: giving up after %d retries: %w", retries, err)
+				}
+				return nil, err
+			}
+			if resp.Request == nil {
+				resp.Request = attempt
+			} else {
+				resp.Request = resp.Request.WithContext(attempt.Context())
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			discard(resp)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether the request of resp should be retried and how long to wait before doing so.
+// The Retry-After header of the response takes precedence over the given backoff.
+func (c *Client) shouldRetry(resp *http.Response, idempotent bool, backoff time.Duration) (time.Duration, bool) {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		if !idempotent {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		return backoff, true
+	}
+	if wait > c.config.maxBackoff() {
+		return 0, false
+	}
+	return wait, true
+}
+
+// backoff returns the wait before the given retry, using exponential backoff with full jitter.
+func (c *Client) backoff(retries int) time.Duration {
+	upper := c.config.maxBackoff()
+	if shift := min(retries, 32); c.config.initialBackoff() < upper>>shift {
+		upper = c.config.initialBackoff() << shift
+	}
+	return time.Duration(rand.Int63n(int64(upper) + 1)) //nolint: gosec
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// discard drains and closes the body of a response which will not be returned, so that the connection can be
+// reused.
+func discard(resp *http.Response) {
+	const maxDrain = 64 << 10
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrain))
+	_ = resp.Body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,303 @@
+package retry_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/retry"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+type attempt struct {
+	statusCode int
+	header     http.Header
+	err        error
+}
+
+type SequenceMock struct {
+	attempts []attempt
+	bodies   []string
+}
+
+func (s *SequenceMock) Do(req *http.Request) (*http.Response, error) {
+	return s.RoundTrip(req)
+}
+
+func (s *SequenceMock) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		s.bodies = append(s.bodies, string(body))
+	} else {
+		s.bodies = append(s.bodies, "")
+	}
+	a := s.attempts[min(len(s.bodies), len(s.attempts))-1]
+	if a.err != nil {
+		return nil, a.err
+	}
+	header := a.header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode: a.statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"status":` + http.StatusText(a.statusCode) + `}`)),
+	}, nil
+}
+
+func TestClient_Do(t *testing.T) {
+	t.Parallel()
+	errNetwork := errors.New("connection reset")
+	config := retry.Config{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	for _, tt := range []struct {
+		name               string
+		method             string
+		header             http.Header
+		attempts           []attempt
+		expectedAttempts   int
+		expectedStatusCode int
+		expectedRetries    int
+		errStr             string
+	}{
+		{
+			name:               "success",
+			method:             http.MethodGet,
+			attempts:           []attempt{{statusCode: http.StatusOK}},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:   "retry on service unavailable",
+			method: http.MethodGet,
+			attempts: []attempt{
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusOK},
+			},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+			expectedRetries:    1,
+		},
+		{
+			name:               "give up after max retries",
+			method:             http.MethodGet,
+			attempts:           []attempt{{statusCode: http.StatusTooManyRequests}},
+			expectedAttempts:   3,
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetries:    2,
+		},
+		{
+			name:               "no retry on bad request",
+			method:             http.MethodGet,
+			attempts:           []attempt{{statusCode: http.StatusBadRequest}},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "retry on network error",
+			method: http.MethodGet,
+			attempts: []attempt{
+				{err: errNetwork},
+				{statusCode: http.StatusOK},
+			},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+			expectedRetries:    1,
+		},
+		{
+			name:             "give up on network error",
+			method:           http.MethodGet,
+			attempts:         []attempt{{err: errNetwork}},
+			expectedAttempts: 3,
+			errStr:           "retry: giving up after 2 retries: connection reset",
+		},
+		{
+			name:   "post retried on too many requests",
+			method: http.MethodPost,
+			attempts: []attempt{
+				{statusCode: http.StatusTooManyRequests},
+				{statusCode: http.StatusOK},
+			},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+			expectedRetries:    1,
+		},
+		{
+			name:               "post not retried on internal server error",
+			method:             http.MethodPost,
+			attempts:           []attempt{{statusCode: http.StatusInternalServerError}},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:             "post not retried on network error",
+			method:           http.MethodPost,
+			attempts:         []attempt{{err: errNetwork}},
+			expectedAttempts: 1,
+			errStr:           "connection reset",
+		},
+		{
+			name:   "post with idempotency key retried on internal server error",
+			method: http.MethodPost,
+			header: http.Header{"Idempotency-Key": []string{"key"}},
+			attempts: []attempt{
+				{statusCode: http.StatusInternalServerError},
+				{statusCode: http.StatusOK},
+			},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+			expectedRetries:    1,
+		},
+		{
+			name:   "retry after in seconds",
+			method: http.MethodGet,
+			attempts: []attempt{
+				{statusCode: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"0"}}},
+				{statusCode: http.StatusOK},
+			},
+			expectedAttempts:   2,
+			expectedStatusCode: http.StatusOK,
+			expectedRetries:    1,
+		},
+		{
+			name:   "retry after longer than max backoff",
+			method: http.MethodGet,
+			attempts: []attempt{
+				{statusCode: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"3600"}}},
+			},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:   "retry after as date",
+			method: http.MethodGet,
+			attempts: []attempt{
+				{
+					statusCode: http.StatusServiceUnavailable,
+					header: http.Header{
+						"Retry-After": []string{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+					},
+				},
+			},
+			expectedAttempts:   1,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := SequenceMock{attempts: tt.attempts}
+			client := retry.NewHTTPClient(&mock, config)
+			req, err := http.NewRequestWithContext(
+				context.Background(), tt.method, "https://router.hereapi.com/v8/routes", http.NoBody,
+			)
+			assert.NilError(t, err)
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			resp, err := client.Do(req)
+			assert.Equal(t, len(mock.bodies), tt.expectedAttempts)
+			if tt.errStr != "" {
+				assert.Error(t, err, tt.errStr)
+				assert.Assert(t, errors.Is(err, errNetwork))
+				return
+			}
+			assert.NilError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, resp.StatusCode, tt.expectedStatusCode)
+			assert.Equal(t, retry.Retries(resp), tt.expectedRetries)
+		})
+	}
+}
+
+func TestClient_Do_ReplaysBody(t *testing.T) {
+	t.Parallel()
+	const body = `{"trace":[{"lat":57.707752,"lng":11.949767},{"lat":59.337492,"lng":18.063672}]}`
+	for _, tt := range []struct {
+		name string
+		body io.Reader
+	}{
+		{
+			name: "with get body",
+			body: bytes.NewReader([]byte(body)),
+		},
+		{
+			name: "without get body",
+			body: io.NopCloser(strings.NewReader(body)),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock := SequenceMock{attempts: []attempt{
+				{statusCode: http.StatusTooManyRequests},
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusOK},
+			}}
+			client := retry.NewHTTPClient(&mock, retry.Config{InitialBackoff: time.Millisecond})
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodPost, "https://router.hereapi.com/v8/import", tt.body,
+			)
+			assert.NilError(t, err)
+			resp, err := client.Do(req)
+			assert.NilError(t, err)
+			defer resp.Body.Close()
+			assert.DeepEqual(t, mock.bodies, []string{body, body, body})
+		})
+	}
+}
+
+func TestClient_Do_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	mock := SequenceMock{attempts: []attempt{{statusCode: http.StatusServiceUnavailable}}}
+	client := retry.NewHTTPClient(&mock, retry.Config{InitialBackoff: time.Hour, MaxBackoff: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://router.hereapi.com/v8/routes", http.NoBody)
+	assert.NilError(t, err)
+	_, err = client.Do(req)
+	assert.Assert(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+	mock := SequenceMock{attempts: []attempt{
+		{statusCode: http.StatusBadGateway},
+		{statusCode: http.StatusOK},
+	}}
+	client := &http.Client{Transport: retry.NewTransport(&mock, retry.Config{InitialBackoff: time.Millisecond})}
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "https://route.ls.hereapi.com/routing/7.2/calculateroute.json", nil,
+	)
+	assert.NilError(t, err)
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, retry.Retries(resp), 1)
+}
+
+func TestResponseError_Retries(t *testing.T) {
+	t.Parallel()
+	mock := SequenceMock{attempts: []attempt{{statusCode: http.StatusTooManyRequests}}}
+	client := routingv8.NewClient(&mock, routingv8.WithMiddleware(retry.NewMiddleware(retry.Config{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+	})))
+	_, err := client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+		TransportMode: routingv8.TransportModeCar,
+	})
+	var responseError *routingv8.ResponseError
+	assert.Assert(t, errors.As(err, &responseError))
+	assert.Equal(t, responseError.HTTPStatusCode, http.StatusTooManyRequests)
+	assert.Equal(t, responseError.Retries, 2)
+}
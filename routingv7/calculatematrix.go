@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"go.einride.tech/here/telemetry"
 )
 
 type CalculateMatrixRequest struct {
@@ -166,16 +168,18 @@ func (s *MatrixService) CalculateMatrix(
 ) (_ *CalculateMatrixResponse, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("calculate matrix: %v", err)
+			err = fmt.Errorf("calculate matrix: %w", err)
 		}
 	}()
+	ctx, op := s.client.startOperation(ctx, "CalculateMatrix", matrixAttributes(req)...)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("calculatematrix.json")
 	if err != nil {
 		return nil, err
 	}
 	r, err := s.client.NewRequest(ctx, u, http.MethodGet, req.QueryString(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp struct {
 		Response CalculateMatrixResponse `json:"response"`
@@ -185,3 +189,12 @@ func (s *MatrixService) CalculateMatrix(
 	}
 	return &resp.Response, nil
 }
+
+// matrixAttributes returns the telemetry attributes of a matrix request.
+func matrixAttributes(req *CalculateMatrixRequest) []telemetry.Attribute {
+	return []telemetry.Attribute{
+		telemetry.String(telemetry.AttributeTransportMode, req.Mode.TransportMode.String()),
+		telemetry.Int(telemetry.AttributeOrigins, len(req.StartWaypoints)),
+		telemetry.Int(telemetry.AttributeDestinations, len(req.DestinationWaypoints)),
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"go.einride.tech/here/telemetry"
 )
 
 type CalculateRouteRequest struct {
@@ -103,20 +105,30 @@ type CalculateRouteResponse struct {
 func (s *RouteService) CalculateRoute(
 	ctx context.Context,
 	req *CalculateRouteRequest,
-) (*CalculateRouteResponse, error) {
+) (_ *CalculateRouteResponse, err error) {
+	ctx, op := s.client.startOperation(ctx, "CalculateRoute", routeAttributes(req.Mode, req.Waypoints)...)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("calculateroute.json")
 	if err != nil {
 		return nil, err
 	}
 	r, err := s.client.NewRequest(ctx, u, http.MethodGet, req.QueryString(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp struct {
 		Response CalculateRouteResponse `json:"response"`
 	}
 	if err = s.client.Do(r, &resp); err != nil {
-		return nil, fmt.Errorf("unable to get routes: %v", err)
+		return nil, fmt.Errorf("unable to get routes: %w", err)
 	}
 	return &resp.Response, nil
 }
+
+// routeAttributes returns the telemetry attributes of a route calculation.
+func routeAttributes(mode RoutingMode, waypoints []WaypointParameter) []telemetry.Attribute {
+	return []telemetry.Attribute{
+		telemetry.String(telemetry.AttributeTransportMode, mode.TransportMode.String()),
+		telemetry.Int(telemetry.AttributeWaypoints, len(waypoints)),
+	}
+}
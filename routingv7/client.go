@@ -8,12 +8,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-)
 
-const (
-	userAgent = "einride/here-go"
+	"go.einride.tech/here/internal/core"
+	"go.einride.tech/here/telemetry"
 )
 
+// HTTPClient sends HTTP requests on behalf of the Client.
+type HTTPClient = core.HTTPClient
+
 // RouteService handles communication with the route-related methods of the v7 HERE API.
 type RouteService service
 
@@ -22,7 +24,9 @@ type MatrixService service
 
 type Client struct {
 	// HTTP client used to communicate with the API.
-	client *http.Client
+	client HTTPClient
+	// Tracer to report operations to, if any.
+	tracer telemetry.Tracer
 
 	UserAgent string
 
@@ -45,28 +49,71 @@ type service struct {
 // An ErrorResponse reports the error caused by an API request.
 // TODO: Consider breaking API change to follow XxxError convention.
 type ErrorResponse struct { //nolint: errname
-	// HTTP response that caused this error
+	// HTTP response that caused this error. Its body can be read again.
 	Response *http.Response
+	// The HTTP body of the error response
+	HTTPBody string
+	// The HTTP status code of the response
+	HTTPStatusCode int
+	// Retries is the number of times the request was retried before this response, when sent through a
+	// retry.NewTransport.
+	Retries int
+	// Body of the error response. Nil if the body is not a v7 error.
+	Body *ErrorBody
+}
+
+// ErrorBody is the body of an error response of the v7 API.
+type ErrorBody struct {
+	// Type of the error, e.g. ApplicationError, PermissionError or SystemError.
+	Type string `json:"type"`
+	// Subtype of the error, e.g. InvalidInputData or NoRouteFound.
+	Subtype string `json:"subtype"`
+	// Details is a human-readable description of the error.
+	Details string `json:"details"`
 }
 
+// Error subtypes of the v7 API.
+const (
+	// ErrorSubtypeNoRouteFound indicates that no route could be found between the waypoints.
+	ErrorSubtypeNoRouteFound = "NoRouteFound"
+	// ErrorSubtypeInvalidInputData indicates that a request parameter is invalid.
+	ErrorSubtypeInvalidInputData = "InvalidInputData"
+)
+
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	msg := fmt.Sprintf("StatusCode: %d", r.HTTPStatusCode)
+	if r.Response != nil && r.Response.Request != nil {
+		msg = fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	}
+	if r.HTTPBody != "" {
+		msg += " Response: " + r.HTTPBody
+	}
+	return msg
+}
+
+// Is reports whether the error is classified by target, one of the sentinel errors such as ErrRateLimited.
+// A response with subtype NoRouteFound is classified as ErrNoRoute, regardless of its status code.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Body != nil && r.Body.Subtype == ErrorSubtypeNoRouteFound {
+		return target == ErrNoRoute
+	}
+	return core.IsStatusError(r.HTTPStatusCode, target)
 }
 
 // New returns a new HERE API client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
-func New(httpClient *http.Client) *Client {
-	if httpClient == nil {
-		httpClient = &http.Client{}
+func New(httpClient *http.Client, opts ...Option) *Client {
+	var client HTTPClient
+	if httpClient != nil {
+		client = httpClient
 	}
-	c := &Client{client: httpClient, UserAgent: userAgent}
+	o := core.NewOptions(client, opts)
+	c := &Client{client: o.Client(), tracer: o.Tracer, UserAgent: o.UserAgent}
 	c.common.client = c
-	routeURL, _ := url.Parse("https://route.ls.hereapi.com/routing/7.2/")
-	c.Route = &RouteService{URL: routeURL, client: c}
-	matrixURL, _ := url.Parse("https://matrix.route.ls.hereapi.com/routing/7.2/")
-	c.Matrix = &MatrixService{URL: matrixURL, client: c}
+	c.Route = &RouteService{URL: o.ServiceURL("https://route.ls.hereapi.com/routing/7.2/"), client: c}
+	c.Matrix = &MatrixService{URL: o.ServiceURL("https://matrix.route.ls.hereapi.com/routing/7.2/"), client: c}
 	return c
 }
 
@@ -81,63 +128,34 @@ func (c *Client) NewRequest(
 	rawQuery string,
 	body interface{},
 ) (*http.Request, error) {
-	if len(rawQuery) > 0 {
-		u.RawQuery = rawQuery
-	}
-	var buf io.ReadWriter
+	var data []byte
 	if body != nil {
-		buf = &bytes.Buffer{}
-		enc := json.NewEncoder(buf)
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
 		enc.SetEscapeHTML(false)
-		err := enc.Encode(body)
-		if err != nil {
+		if err := enc.Encode(body); err != nil {
 			return nil, err
 		}
+		data = buf.Bytes()
 	}
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
-	if err != nil {
-		return nil, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
-	}
-	return req, nil
+	return core.NewRequest(ctx, c.UserAgent, u, method, rawQuery, data)
+}
+
+// startOperation starts an operation, reported to the tracer and metrics of the Client. Requests created with the
+// returned context are reported as part of the operation, which must be ended with the result of the service call.
+func (c *Client) startOperation(
+	ctx context.Context,
+	name string,
+	attributes ...telemetry.Attribute,
+) (context.Context, *core.Operation) {
+	return core.StartOperation(ctx, c.tracer, "routingv7", name, attributes...)
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if rerr := resp.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
-	err = CheckResponse(resp)
-	if err != nil {
-		return err
-	}
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return err
+	return core.Do(c.client, req, v, core.DecodeJSON, newErrorResponse)
 }
 
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
@@ -146,6 +164,25 @@ func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
-	errorResponse := &ErrorResponse{Response: r}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return newErrorResponse(r, body)
+}
+
+// newErrorResponse returns the error of a response with a status code outside the 200 range.
+func newErrorResponse(r *http.Response, body []byte) error {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	errorResponse := &ErrorResponse{
+		Response:       r,
+		HTTPBody:       string(body),
+		HTTPStatusCode: r.StatusCode,
+		Retries:        core.Retries(r),
+	}
+	var errorBody ErrorBody
+	if err := json.Unmarshal(body, &errorBody); err == nil && errorBody.Type != "" {
+		errorResponse.Body = &errorBody
+	}
 	return errorResponse
 }
@@ -0,0 +1,141 @@
+package routingv7_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.einride.tech/here/routingv7"
+	"gotest.tools/v3/assert"
+)
+
+type responseRoundTripper struct {
+	statusCode int
+	body       string
+	requests   []*http.Request
+}
+
+func (r *responseRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+	return &http.Response{
+		StatusCode: r.statusCode,
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	t.Parallel()
+	transport := responseRoundTripper{
+		statusCode: http.StatusBadRequest,
+		body:       `{"type":"ApplicationError","subtype":"InvalidInputData"}`,
+	}
+	client := routingv7.New(&http.Client{Transport: &transport})
+	u, err := client.Route.URL.Parse("calculateroute.json")
+	assert.NilError(t, err)
+	req, err := client.NewRequest(context.Background(), u, http.MethodGet, "", nil)
+	assert.NilError(t, err)
+	err = client.Do(req, nil)
+	var errorResponse *routingv7.ErrorResponse
+	assert.Assert(t, errors.As(err, &errorResponse))
+	assert.Equal(t, errorResponse.HTTPStatusCode, http.StatusBadRequest)
+	assert.Equal(t, errorResponse.HTTPBody, transport.body)
+	assert.Error(
+		t,
+		err,
+		"GET https://route.ls.hereapi.com/routing/7.2/calculateroute.json: 400 Response: "+transport.body,
+	)
+	// The body of the response can be read again.
+	body, err := io.ReadAll(errorResponse.Response.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, string(body), transport.body)
+}
+
+func TestClient_WithBaseURL(t *testing.T) {
+	t.Parallel()
+	baseURL, err := url.Parse("http://127.0.0.1:8080")
+	assert.NilError(t, err)
+	transport := responseRoundTripper{statusCode: http.StatusOK, body: `{"response":{}}`}
+	client := routingv7.New(&http.Client{Transport: &transport}, routingv7.WithBaseURL(baseURL))
+	_, err = client.Route.CalculateRoute(context.Background(), &routingv7.CalculateRouteRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(transport.requests), 1)
+	assert.Equal(t, transport.requests[0].URL.Host, "127.0.0.1:8080")
+	assert.Equal(t, transport.requests[0].URL.Path, "/routing/7.2/calculateroute.json")
+}
+
+func TestRouteService_CalculateRoute_ErrorClassification(t *testing.T) {
+	t.Parallel()
+	request := &routingv7.CalculateRouteRequest{
+		Waypoints: []routingv7.WaypointParameter{
+			&routingv7.GeoWaypoint{Lat: 57.70775, Long: 11.94977},
+			&routingv7.GeoWaypoint{Lat: 59.33749, Long: 18.06367},
+		},
+	}
+	for _, tt := range []struct {
+		name         string
+		statusCode   int
+		body         string
+		expectedBody *routingv7.ErrorBody
+		expected     error
+		unexpected   error
+	}{
+		{
+			name:       "no route found",
+			statusCode: http.StatusBadRequest,
+			body: `{"type":"ApplicationError","subtype":"NoRouteFound",` +
+				`"details":"Error is NGEO_ERROR_GRAPH_DISCONNECTED"}`,
+			expectedBody: &routingv7.ErrorBody{
+				Type:    "ApplicationError",
+				Subtype: routingv7.ErrorSubtypeNoRouteFound,
+				Details: "Error is NGEO_ERROR_GRAPH_DISCONNECTED",
+			},
+			expected:   routingv7.ErrNoRoute,
+			unexpected: routingv7.ErrBadRequest,
+		},
+		{
+			name:       "invalid input",
+			statusCode: http.StatusBadRequest,
+			body:       `{"type":"ApplicationError","subtype":"InvalidInputData","details":"Invalid value for waypoint0"}`,
+			expectedBody: &routingv7.ErrorBody{
+				Type:    "ApplicationError",
+				Subtype: routingv7.ErrorSubtypeInvalidInputData,
+				Details: "Invalid value for waypoint0",
+			},
+			expected:   routingv7.ErrBadRequest,
+			unexpected: routingv7.ErrNoRoute,
+		},
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":"Unauthorized","error_description":"apiKey invalid"}`,
+			expected:   routingv7.ErrUnauthorized,
+			unexpected: routingv7.ErrForbidden,
+		},
+		{
+			name:       "service unavailable",
+			statusCode: http.StatusServiceUnavailable,
+			body:       "Service Unavailable",
+			expected:   routingv7.ErrServiceUnavailable,
+			unexpected: routingv7.ErrRateLimited,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := routingv7.New(&http.Client{
+				Transport: &responseRoundTripper{statusCode: tt.statusCode, body: tt.body},
+			})
+			_, err := client.Route.CalculateRoute(context.Background(), request)
+			assert.Assert(t, errors.Is(err, tt.expected))
+			assert.Assert(t, !errors.Is(err, tt.unexpected))
+			var errorResponse *routingv7.ErrorResponse
+			assert.Assert(t, errors.As(err, &errorResponse))
+			assert.DeepEqual(t, errorResponse.Body, tt.expectedBody)
+		})
+	}
+}
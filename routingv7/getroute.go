@@ -54,9 +54,11 @@ func (s *RouteService) GetRoute(
 ) (_ *GetRouteResponse, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("get route %s: %v", req.RouteID, err)
+			err = fmt.Errorf("get route %s: %w", req.RouteID, err)
 		}
 	}()
+	ctx, op := s.client.startOperation(ctx, "GetRoute", routeAttributes(req.Mode, req.Waypoints)...)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("getroute.json")
 	if err != nil {
 		return nil, err
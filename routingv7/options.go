@@ -0,0 +1,44 @@
+package routingv7
+
+import (
+	"net/url"
+
+	"go.einride.tech/here/internal/core"
+	"go.einride.tech/here/telemetry"
+)
+
+// Option configures a Client.
+type Option = core.Option
+
+// Middleware wraps the HTTPClient of a Client, e.g. to retry, rate limit or cache requests.
+type Middleware = core.Middleware
+
+// WithBaseURL sets the base URL of every service of the Client, keeping service paths such as /routing/7.2/.
+func WithBaseURL(baseURL *url.URL) Option {
+	return core.WithBaseURL(baseURL)
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return core.WithUserAgent(userAgent)
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, replacing the one passed to New.
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return core.WithHTTPClient(httpClient)
+}
+
+// WithMiddleware wraps the HTTP client of the Client with the given middleware, in order.
+func WithMiddleware(middleware ...Middleware) Option {
+	return core.WithMiddleware(middleware...)
+}
+
+// WithTracer reports every service call of the Client, e.g. CalculateRoute, and the requests it sends as spans.
+func WithTracer(tracer telemetry.Tracer) Option {
+	return core.WithTracer(tracer)
+}
+
+// WithMetrics records a measurement of every request sent by the Client.
+func WithMetrics(metrics telemetry.Metrics) Option {
+	return core.WithMetrics(metrics)
+}
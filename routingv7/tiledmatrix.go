@@ -0,0 +1,146 @@
+package routingv7
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Matrix limits of a single CalculateMatrix request.
+const (
+	maxStartsPerMatrix           = 15
+	maxStartsPerSingleDestMatrix = 100
+	maxDestinationsPerMatrix     = 100
+	defaultMaxTileConcurrency    = 4
+)
+
+type CalculateTiledMatrixRequest struct {
+	// Request to split into tiles. Each tile is calculated with the same parameters as the request, but with a
+	// subset of the start and destination waypoints.
+	Request *CalculateMatrixRequest
+	// MaxStartsPerTile is the maximum number of starts in each tile.
+	// Defaults to 15, or 100 when there is a single destination.
+	MaxStartsPerTile int
+	// MaxDestinationsPerTile is the maximum number of destinations in each tile. Defaults to 100.
+	MaxDestinationsPerTile int
+	// MaxConcurrency is the maximum number of tiles calculated at the same time. Defaults to 4.
+	MaxConcurrency int
+}
+
+// CalculateTiledMatrix returns a matrix of route summaries for start and destination sets that are larger than
+// the limits of a single CalculateMatrix request.
+// The waypoints are partitioned into tiles which are calculated concurrently and merged into a single response.
+// The StartIndex and DestinationIndex of each entry refer to the waypoints of the original request.
+func (s *MatrixService) CalculateTiledMatrix(
+	ctx context.Context,
+	req *CalculateTiledMatrixRequest,
+) (_ *CalculateMatrixResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("calculate tiled matrix: %w", err)
+		}
+	}()
+	if req.Request == nil {
+		return nil, fmt.Errorf("request must be provided")
+	}
+	if len(req.Request.StartWaypoints) == 0 || len(req.Request.DestinationWaypoints) == 0 {
+		return nil, fmt.Errorf("start and destination waypoints must be provided")
+	}
+	ctx, op := s.client.startOperation(ctx, "CalculateTiledMatrix", matrixAttributes(req.Request)...)
+	defer func() { op.End(err) }()
+	numStarts, numDestinations := len(req.Request.StartWaypoints), len(req.Request.DestinationWaypoints)
+	maxStarts := req.MaxStartsPerTile
+	if maxStarts <= 0 {
+		maxStarts = maxStartsPerMatrix
+		if numDestinations == 1 {
+			maxStarts = maxStartsPerSingleDestMatrix
+		}
+	}
+	maxDestinations := req.MaxDestinationsPerTile
+	if maxDestinations <= 0 {
+		maxDestinations = maxDestinationsPerMatrix
+	}
+	concurrency := req.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultMaxTileConcurrency
+	}
+	tiles := matrixTiles(numStarts, numDestinations, maxStarts, maxDestinations)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	responses := make([]*CalculateMatrixResponse, len(tiles))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var tileErr error
+	for i, tile := range tiles {
+		i, tile := i, tile
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-semaphore }()
+			startEnd := tile.startOffset + tile.numStarts
+			destinationEnd := tile.destinationOffset + tile.numDestinations
+			tileReq := *req.Request
+			tileReq.StartWaypoints = tileReq.StartWaypoints[tile.startOffset:startEnd]
+			tileReq.DestinationWaypoints = tileReq.DestinationWaypoints[tile.destinationOffset:destinationEnd]
+			resp, err := s.CalculateMatrix(ctx, &tileReq)
+			if err != nil {
+				errOnce.Do(func() {
+					tileErr = fmt.Errorf("tile %d: %w", i, err)
+					cancel()
+				})
+				return
+			}
+			responses[i] = resp
+		}()
+	}
+	wg.Wait()
+	if tileErr != nil {
+		return nil, tileErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	merged := CalculateMatrixResponse{
+		MatrixEntries: make([]RouteMatrixEntry, 0, numStarts*numDestinations),
+	}
+	for i, tile := range tiles {
+		if i == 0 {
+			merged.MetaInfo = responses[i].MetaInfo
+		}
+		for _, entry := range responses[i].MatrixEntries {
+			entry.StartIndex += tile.startOffset
+			entry.DestinationIndex += tile.destinationOffset
+			merged.MatrixEntries = append(merged.MatrixEntries, entry)
+		}
+	}
+	return &merged, nil
+}
+
+// matrixTile is a rectangular part of a matrix.
+type matrixTile struct {
+	startOffset       int
+	numStarts         int
+	destinationOffset int
+	numDestinations   int
+}
+
+func matrixTiles(numStarts, numDestinations, maxStarts, maxDestinations int) []matrixTile {
+	var tiles []matrixTile
+	for s := 0; s < numStarts; s += maxStarts {
+		for d := 0; d < numDestinations; d += maxDestinations {
+			tiles = append(tiles, matrixTile{
+				startOffset:       s,
+				numStarts:         min(maxStarts, numStarts-s),
+				destinationOffset: d,
+				numDestinations:   min(maxDestinations, numDestinations-d),
+			})
+		}
+	}
+	return tiles
+}
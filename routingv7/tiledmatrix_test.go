@@ -0,0 +1,119 @@
+package routingv7_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"go.einride.tech/here/routingv7"
+	"gotest.tools/v3/assert"
+)
+
+// matrixRoundTripper calculates a matrix where the distance between a start and a destination is
+// start.Lat*1000 + destination.Lat.
+type matrixRoundTripper struct {
+	calls int32
+}
+
+func (m *matrixRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&m.calls, 1)
+	query := req.URL.Query()
+	lats := func(prefix string) []float64 {
+		var result []float64
+		for i := 0; query.Has(fmt.Sprintf("%s%d", prefix, i)); i++ {
+			latLng := strings.TrimPrefix(query.Get(fmt.Sprintf("%s%d", prefix, i)), "geo!")
+			lat, err := strconv.ParseFloat(strings.Split(latLng, ",")[0], 64)
+			if err != nil {
+				panic(err)
+			}
+			result = append(result, lat)
+		}
+		return result
+	}
+	var resp struct {
+		Response routingv7.CalculateMatrixResponse `json:"response"`
+	}
+	resp.Response.MetaInfo.RequestID = "request"
+	for i, start := range lats("start") {
+		for j, destination := range lats("destination") {
+			resp.Response.MatrixEntries = append(resp.Response.MatrixEntries, routingv7.RouteMatrixEntry{
+				StartIndex:       i,
+				DestinationIndex: j,
+				Summary: routingv7.MatrixRouteSummary{
+					DistanceMeters: start*1000 + destination,
+				},
+			})
+		}
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(b)),
+		Request:    req,
+	}, nil
+}
+
+func TestMatrixService_CalculateTiledMatrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const numStarts, numDestinations = 20, 3
+	starts := make([]routingv7.WaypointParameter, 0, numStarts)
+	for i := 0; i < numStarts; i++ {
+		starts = append(starts, &routingv7.GeoWaypoint{Lat: float64(i)})
+	}
+	destinations := make([]routingv7.WaypointParameter, 0, numDestinations)
+	for i := 0; i < numDestinations; i++ {
+		destinations = append(destinations, &routingv7.GeoWaypoint{Lat: float64(i)})
+	}
+	transport := matrixRoundTripper{}
+	client := routingv7.New(&http.Client{Transport: &transport})
+
+	got, err := client.Matrix.CalculateTiledMatrix(ctx, &routingv7.CalculateTiledMatrixRequest{
+		Request: &routingv7.CalculateMatrixRequest{
+			StartWaypoints:       starts,
+			DestinationWaypoints: destinations,
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, transport.calls, int32(2))
+	assert.Equal(t, got.MetaInfo.RequestID, "request")
+	assert.Equal(t, len(got.MatrixEntries), numStarts*numDestinations)
+	for _, entry := range got.MatrixEntries {
+		assert.Equal(t, entry.Summary.DistanceMeters, float64(entry.StartIndex*1000+entry.DestinationIndex))
+	}
+}
+
+func TestMatrixService_CalculateTiledMatrix_MissingWaypoints(t *testing.T) {
+	t.Parallel()
+	waypoints := []routingv7.WaypointParameter{&routingv7.GeoWaypoint{Lat: 1}}
+	for _, tt := range []struct {
+		name    string
+		request *routingv7.CalculateMatrixRequest
+	}{
+		{name: "no starts", request: &routingv7.CalculateMatrixRequest{DestinationWaypoints: waypoints}},
+		{name: "no destinations", request: &routingv7.CalculateMatrixRequest{StartWaypoints: waypoints}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transport := matrixRoundTripper{}
+			client := routingv7.New(&http.Client{Transport: &transport})
+			_, err := client.Matrix.CalculateTiledMatrix(context.Background(), &routingv7.CalculateTiledMatrixRequest{
+				Request: tt.request,
+			})
+			assert.Error(t, err, "calculate tiled matrix: start and destination waypoints must be provided")
+			assert.Equal(t, transport.calls, int32(0))
+		})
+	}
+}
@@ -3,9 +3,13 @@ package routingv8
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
+
+	"go.einride.tech/here/telemetry"
 )
 
 func (c *CalculateMatrixRequest) QueryString() string {
@@ -24,9 +28,11 @@ func (s *MatrixService) CalculateMatrix(
 ) (_ *CalculateMatrixResponse, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("calculate matrix: %v", err)
+			err = fmt.Errorf("calculate matrix: %w", err)
 		}
 	}()
+	ctx, op := s.Client.startOperation(ctx, "CalculateMatrix", matrixAttributes(req.Body)...)
+	defer func() { op.End(err) }()
 	u, err := s.URL.Parse("matrix")
 	if err != nil {
 		return nil, err
@@ -37,7 +43,190 @@ func (s *MatrixService) CalculateMatrix(
 	}
 	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, req.QueryString(), bytes)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create post request: %v", err)
+		return nil, fmt.Errorf("unable to create post request: %w", err)
+	}
+	var resp CalculateMatrixResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+const (
+	defaultMatrixPollInterval    = time.Second
+	defaultMaxMatrixPollInterval = 30 * time.Second
+)
+
+// CalculateMatrixAsync starts an asynchronous matrix calculation, regardless of the Async flag of the request.
+// Use WaitForMatrix with the returned MatrixID to wait for and download the result.
+// See https://www.here.com/docs/bundle/matrix-routing-api-developer-guide-v8/page/topics/get-started/asynchronous-request.html
+// for details.
+func (s *MatrixService) CalculateMatrixAsync(
+	ctx context.Context,
+	req *CalculateMatrixRequest,
+) (_ *MatrixStatusResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("calculate matrix async: %w", err)
+		}
+	}()
+	ctx, op := s.Client.startOperation(ctx, "CalculateMatrixAsync", matrixAttributes(req.Body)...)
+	defer func() { op.End(err) }()
+	u, err := s.URL.Parse("matrix")
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := json.Marshal(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	query := CalculateMatrixRequest{Async: true}
+	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, query.QueryString(), bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create post request: %w", err)
+	}
+	var resp MatrixStatusResponse
+	if err := s.Client.Do(r, &resp); err != nil {
+		return nil, err
+	}
+	if resp.MatrixID == "" {
+		return nil, fmt.Errorf("missing matrix ID in response")
+	}
+	return &resp, nil
+}
+
+// MatrixStatus returns the status of an asynchronous matrix calculation.
+func (s *MatrixService) MatrixStatus(
+	ctx context.Context,
+	req *MatrixStatusRequest,
+) (_ *MatrixStatusResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("matrix status %s: %w", req.MatrixID, err)
+		}
+	}()
+	ctx, op := s.Client.startOperation(ctx, "MatrixStatus")
+	defer func() { op.End(err) }()
+	resp, _, err := s.matrixStatus(ctx, req.MatrixID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WaitForMatrix polls the status of an asynchronous matrix calculation with exponential backoff until it is
+// completed, and then downloads the result. Polling stops when the context is cancelled.
+func (s *MatrixService) WaitForMatrix(
+	ctx context.Context,
+	req *WaitForMatrixRequest,
+) (_ *CalculateMatrixResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("wait for matrix %s: %w", req.MatrixID, err)
+		}
+	}()
+	ctx, op := s.Client.startOperation(ctx, "WaitForMatrix")
+	defer func() { op.End(err) }()
+	if req.MatrixID == "" {
+		return nil, fmt.Errorf("matrix ID must be provided")
+	}
+	interval := req.PollInterval
+	if interval <= 0 {
+		interval = defaultMatrixPollInterval
+	}
+	maxInterval := req.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxMatrixPollInterval
+	}
+	for {
+		status, result, err := s.matrixStatus(ctx, req.MatrixID)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			// The HTTP client followed the redirect to the result.
+			return result, nil
+		}
+		switch status.Status {
+		case MatrixStatusCompleted:
+			return s.downloadMatrix(ctx, status)
+		case MatrixStatusFailed, MatrixStatusTimedOut:
+			if status.Error != nil {
+				return nil, &ResponseError{Response: status.Error, HTTPStatusCode: status.Error.Status}
+			}
+			return nil, fmt.Errorf("calculation %s", status.Status)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// matrixStatus requests the status of a matrix calculation.
+// HERE responds with a redirect to the result when the calculation is completed. If the HTTP client follows the
+// redirect the result is returned, otherwise the status carries the URL of the result.
+func (s *MatrixService) matrixStatus(
+	ctx context.Context,
+	matrixID string,
+) (*MatrixStatusResponse, *CalculateMatrixResponse, error) {
+	if matrixID == "" {
+		return nil, nil, fmt.Errorf("matrix ID must be provided")
+	}
+	u, err := s.URL.Parse(fmt.Sprintf("matrix/%s/status", url.PathEscape(matrixID)))
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create get request: %w", err)
+	}
+	var resp struct {
+		MatrixStatusResponse
+		Matrix           *MatrixResponse  `json:"matrix"`
+		RegionDefinition RegionDefinition `json:"regionDefinition"`
+	}
+	if err := s.Client.Do(r, &resp); err != nil {
+		var responseError *ResponseError
+		if !errors.As(err, &responseError) || responseError.HTTPStatusCode != http.StatusSeeOther {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal([]byte(responseError.HTTPBody), &resp.MatrixStatusResponse); err != nil {
+			return nil, nil, err
+		}
+	}
+	if resp.Matrix != nil {
+		return nil, &CalculateMatrixResponse{
+			MatrixID:         resp.MatrixID,
+			Matrix:           *resp.Matrix,
+			RegionDefinition: resp.RegionDefinition,
+		}, nil
+	}
+	return &resp.MatrixStatusResponse, nil, nil
+}
+
+func (s *MatrixService) downloadMatrix(
+	ctx context.Context,
+	status *MatrixStatusResponse,
+) (*CalculateMatrixResponse, error) {
+	resultURL := status.ResultURL
+	if resultURL == "" {
+		resultURL = fmt.Sprintf("matrix/%s", url.PathEscape(status.MatrixID))
+	}
+	u, err := s.URL.Parse(resultURL)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp CalculateMatrixResponse
 	if err := s.Client.Do(r, &resp); err != nil {
@@ -45,3 +234,23 @@ func (s *MatrixService) CalculateMatrix(
 	}
 	return &resp, nil
 }
+
+// matrixAttributes returns the telemetry attributes of a matrix calculation. Without destinations, the origins are
+// also the destinations.
+func matrixAttributes(body *CalculateMatrixBody) []telemetry.Attribute {
+	if body == nil {
+		return nil
+	}
+	destinations := len(body.Destinations)
+	if destinations == 0 {
+		destinations = len(body.Origins)
+	}
+	attributes := []telemetry.Attribute{
+		telemetry.Int(telemetry.AttributeOrigins, len(body.Origins)),
+		telemetry.Int(telemetry.AttributeDestinations, destinations),
+	}
+	if tm := body.TransportMode.String(); tm != unspecified {
+		attributes = append(attributes, telemetry.String(telemetry.AttributeTransportMode, tm))
+	}
+	return attributes
+}
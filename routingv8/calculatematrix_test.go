@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"go.einride.tech/here/routingv8"
 	"gotest.tools/v3/assert"
@@ -84,3 +87,139 @@ func TestMatrixService_CalculateMatrix(t *testing.T) {
 	assert.NilError(t, err)
 	assert.DeepEqual(t, &exp, got)
 }
+
+type AsyncMatrixMock struct {
+	mu               sync.Mutex
+	pendingPolls     int
+	redirect         bool
+	result           routingv8.CalculateMatrixResponse
+	requestedURLs    []string
+	requestedQueries []string
+}
+
+func (c *AsyncMatrixMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestedURLs = append(c.requestedURLs, req.Method+" "+req.URL.Path)
+	c.requestedQueries = append(c.requestedQueries, req.URL.RawQuery)
+	status := http.StatusOK
+	var body interface{}
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == "/v8/matrix":
+		status = http.StatusAccepted
+		body = routingv8.MatrixStatusResponse{
+			MatrixID:  "123",
+			Status:    routingv8.MatrixStatusAccepted,
+			StatusURL: "https://matrix.router.hereapi.com/v8/matrix/123/status",
+		}
+	case req.URL.Path == "/v8/matrix/123/status" && c.pendingPolls > 0:
+		c.pendingPolls--
+		body = routingv8.MatrixStatusResponse{MatrixID: "123", Status: routingv8.MatrixStatusInProgress}
+	case req.URL.Path == "/v8/matrix/123/status" && c.redirect:
+		status = http.StatusSeeOther
+		body = routingv8.MatrixStatusResponse{
+			MatrixID:  "123",
+			Status:    routingv8.MatrixStatusCompleted,
+			ResultURL: "https://matrix.router.hereapi.com/v8/matrix/123",
+		}
+	case req.URL.Path == "/v8/matrix/123/status", req.URL.Path == "/v8/matrix/123":
+		body = c.result
+	default:
+		status = http.StatusNotFound
+		body = routingv8.HereErrorResponse{Status: http.StatusNotFound, Title: "Not found"}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	headers.Add("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode:    status,
+		Header:        headers,
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+	}, nil
+}
+
+func TestMatrixService_WaitForMatrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exp := routingv8.CalculateMatrixResponse{
+		MatrixID: "123",
+		Matrix: routingv8.MatrixResponse{
+			NumOrigins:      1,
+			NumDestinations: 1,
+			TravelTimes:     []int32{10},
+			Distances:       []int32{100},
+			ErrorCodes:      routingv8.ErrorCodes{routingv8.ErrorCodeSuccess},
+		},
+		RegionDefinition: routingv8.RegionDefinition{
+			Type: routingv8.RegionTypeWorld,
+		},
+	}
+	for _, tt := range []struct {
+		name          string
+		redirect      bool
+		expectedCalls []string
+	}{
+		{
+			name:     "redirect followed by http client",
+			redirect: false,
+			expectedCalls: []string{
+				"POST /v8/matrix",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+			},
+		},
+		{
+			name:     "redirect returned to caller",
+			redirect: true,
+			expectedCalls: []string{
+				"POST /v8/matrix",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123/status",
+				"GET /v8/matrix/123",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			httpClient := AsyncMatrixMock{pendingPolls: 2, redirect: tt.redirect, result: exp}
+			routingClient := routingv8.NewClient(&httpClient)
+			status, err := routingClient.Matrix.CalculateMatrixAsync(ctx, &routingv8.CalculateMatrixRequest{
+				Body: &routingv8.CalculateMatrixBody{
+					RegionDefinition: routingv8.RegionDefinition{
+						Type: routingv8.RegionTypeWorld,
+					},
+				},
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, status.Status, routingv8.MatrixStatusAccepted)
+			got, err := routingClient.Matrix.WaitForMatrix(ctx, &routingv8.WaitForMatrixRequest{
+				MatrixID:     status.MatrixID,
+				PollInterval: time.Millisecond,
+			})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &exp, got)
+			assert.DeepEqual(t, httpClient.requestedURLs, tt.expectedCalls)
+			assert.Equal(t, httpClient.requestedQueries[0], "async=true")
+		})
+	}
+}
+
+func TestMatrixService_WaitForMatrix_Cancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	httpClient := AsyncMatrixMock{pendingPolls: math.MaxInt}
+	routingClient := routingv8.NewClient(&httpClient)
+	_, err := routingClient.Matrix.WaitForMatrix(ctx, &routingv8.WaitForMatrixRequest{
+		MatrixID:     "123",
+		PollInterval: time.Millisecond,
+	})
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+}
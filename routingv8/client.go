@@ -1,22 +1,17 @@
 package routingv8
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-)
 
-const (
-	userAgent = "einride/here-go"
+	"go.einride.tech/here/internal/core"
+	"go.einride.tech/here/telemetry"
 )
 
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+// HTTPClient sends HTTP requests on behalf of the Client.
+type HTTPClient = core.HTTPClient
 
 // MatrixService handles communication with the matrix-related methods of the HERE API.
 type MatrixService service
@@ -27,6 +22,8 @@ type RoutingService service
 type Client struct {
 	// HTTP client used to communicate with the API.
 	client HTTPClient
+	// Tracer to report operations to, if any.
+	tracer telemetry.Tracer
 
 	UserAgent string
 
@@ -49,39 +46,29 @@ type ResponseError struct {
 	HTTPBody string
 	// The HTTP status code of the response
 	HTTPStatusCode int
+	// Retries is the number of times the request was retried before this response, when sent through a
+	// retry.Client.
+	Retries int
 }
 
 func (r *ResponseError) Error() string {
-	if r.Response == nil || r.Response.Status == 0 {
-		return fmt.Sprintf(
-			"Response: %s StatusCode: %d",
-			r.HTTPBody,
-			r.HTTPStatusCode,
-		)
-	}
-	return fmt.Sprintf(
-		"Title: %v, Status: %d, Code: %v, Cause: %v, Action: %v",
-		r.Response.Title,
-		r.Response.Status,
-		r.Response.Code,
-		r.Response.Cause,
-		r.Response.Action,
-	)
+	return core.ErrorMessage((*core.HereError)(r.Response), r.HTTPBody, r.HTTPStatusCode)
+}
+
+// Is reports whether the error is classified by target, one of the sentinel errors such as ErrRateLimited.
+func (r *ResponseError) Is(target error) bool {
+	return core.IsStatusError(r.HTTPStatusCode, target)
 }
 
 // NewClient returns a new HERE API Client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient HTTPClient) *Client {
-	if httpClient == nil {
-		httpClient = &http.Client{}
-	}
-	c := &Client{client: httpClient, UserAgent: userAgent}
-	matrixURL, _ := url.Parse("https://matrix.router.hereapi.com/v8/")
-	c.Matrix = &MatrixService{URL: matrixURL, Client: c}
-	routingURL, _ := url.Parse("https://router.hereapi.com/v8/")
-	c.Routing = &RoutingService{URL: routingURL, Client: c}
+func NewClient(httpClient HTTPClient, opts ...Option) *Client {
+	o := core.NewOptions(httpClient, opts)
+	c := &Client{client: o.Client(), tracer: o.Tracer, UserAgent: o.UserAgent}
+	c.Matrix = &MatrixService{URL: o.ServiceURL("https://matrix.router.hereapi.com/v8/"), Client: c}
+	c.Routing = &RoutingService{URL: o.ServiceURL("https://router.hereapi.com/v8/"), Client: c}
 	return c
 }
 
@@ -96,78 +83,37 @@ func (c *Client) NewRequest(
 	rawQuery string,
 	body []byte,
 ) (*http.Request, error) {
-	if len(rawQuery) > 0 {
-		u.RawQuery = rawQuery
-	}
-	var r io.Reader
-	if len(body) > 0 {
-		r = bytes.NewReader(body)
-	}
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
-	if err != nil {
-		return nil, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
-	}
-	return req, nil
+	return core.NewRequest(ctx, c.UserAgent, u, method, rawQuery, body)
+}
+
+// startOperation starts an operation, reported to the tracer and metrics of the Client. Requests created with the
+// returned context are reported as part of the operation, which must be ended with the result of the service call.
+func (c *Client) startOperation(
+	ctx context.Context,
+	name string,
+	attributes ...telemetry.Attribute,
+) (context.Context, *core.Operation) {
+	return core.StartOperation(ctx, c.tracer, "routingv8", name, attributes...)
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if rerr := resp.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
-	err = checkResponse(resp)
-	if err != nil {
-		return err
-	}
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return err
+	return core.Do(c.client, req, v, core.DecodeJSON, newResponseError)
 }
 
-// checkResponse checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range.
-func checkResponse(r *http.Response) error {
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
-	}
-	buf := new(bytes.Buffer)
-	_, err := io.Copy(buf, r.Body)
-	if err != nil {
-		return err
+// newResponseError returns the error of a response with a status code outside the 200 range.
+func newResponseError(r *http.Response, body []byte) error {
+	responseError := &ResponseError{
+		HTTPBody:       string(body),
+		HTTPStatusCode: r.StatusCode,
+		Retries:        core.Retries(r),
 	}
 	var response HereErrorResponse
-	err = json.Unmarshal(buf.Bytes(), &response)
-	if err != nil {
-		return err
-	}
-	return &ResponseError{
-		Response:       &response,
-		HTTPBody:       buf.String(),
-		HTTPStatusCode: r.StatusCode,
+	// Not a HERE error, e.g. the status of an asynchronous calculation, keeps only the raw body.
+	if err := json.Unmarshal(body, &response); err == nil {
+		responseError.Response = &response
 	}
+	return responseError
 }
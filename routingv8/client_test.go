@@ -1,6 +1,9 @@
 package routingv8_test
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"go.einride.tech/here/routingv8"
@@ -58,3 +61,38 @@ func TestRouteError(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseError_Is(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		statusCode int
+		expected   error
+	}{
+		{statusCode: http.StatusBadRequest, expected: routingv8.ErrBadRequest},
+		{statusCode: http.StatusUnauthorized, expected: routingv8.ErrUnauthorized},
+		{statusCode: http.StatusForbidden, expected: routingv8.ErrForbidden},
+		{statusCode: http.StatusTooManyRequests, expected: routingv8.ErrRateLimited},
+		{statusCode: http.StatusBadGateway, expected: routingv8.ErrServiceUnavailable},
+		{statusCode: http.StatusServiceUnavailable, expected: routingv8.ErrServiceUnavailable},
+		{statusCode: http.StatusGatewayTimeout, expected: routingv8.ErrServiceUnavailable},
+		{statusCode: http.StatusInternalServerError},
+		{statusCode: http.StatusNotFound},
+	} {
+		tt := tt
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			t.Parallel()
+			// Wrapped, as returned by the MatrixService.
+			err := fmt.Errorf("calculate matrix: %w", &routingv8.ResponseError{HTTPStatusCode: tt.statusCode})
+			for _, sentinel := range []error{
+				routingv8.ErrBadRequest,
+				routingv8.ErrUnauthorized,
+				routingv8.ErrForbidden,
+				routingv8.ErrRateLimited,
+				routingv8.ErrNoRoute,
+				routingv8.ErrServiceUnavailable,
+			} {
+				assert.Equal(t, errors.Is(err, sentinel), sentinel == tt.expected, sentinel.Error())
+			}
+		})
+	}
+}
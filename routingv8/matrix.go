@@ -0,0 +1,163 @@
+package routingv8
+
+import (
+	"fmt"
+	"time"
+
+	"go.einride.tech/here/routingv7"
+)
+
+func (e ErrorCode) String() string {
+	switch e {
+	case ErrorCodeSuccess:
+		return "success"
+	case ErrorCodeDisconnected:
+		return "disconnected"
+	case ErrorCodeMatchingFailed:
+		return "matchingFailed"
+	case ErrorCodeParameterViolation:
+		return "parameterViolation"
+	case ErrorCodeUnknown:
+		return "unknown"
+	default:
+		return invalid
+	}
+}
+
+// MatrixCellError reports that no route could be calculated between an origin and a destination of a matrix.
+type MatrixCellError struct {
+	// OriginIndex of the failed route.
+	OriginIndex int
+	// DestinationIndex of the failed route.
+	DestinationIndex int
+	// Code describing why the route failed.
+	Code ErrorCode
+}
+
+func (e *MatrixCellError) Error() string {
+	return fmt.Sprintf("origin %d to destination %d: %s (%d)", e.OriginIndex, e.DestinationIndex, e.Code, int(e.Code))
+}
+
+// Is reports whether the error is classified by target. Routes between disconnected waypoints, or waypoints which
+// could not be matched to the road network, are classified as ErrNoRoute, and parameter violations as
+// ErrBadRequest.
+func (e *MatrixCellError) Is(target error) bool {
+	switch e.Code {
+	case ErrorCodeDisconnected, ErrorCodeMatchingFailed:
+		return target == ErrNoRoute
+	case ErrorCodeParameterViolation:
+		return target == ErrBadRequest
+	default:
+		return false
+	}
+}
+
+// MatrixCell is the route summary between one origin and one destination of a matrix.
+type MatrixCell struct {
+	// OriginIndex of the route in the list of origins.
+	OriginIndex int
+	// DestinationIndex of the route in the list of destinations.
+	DestinationIndex int
+	// TravelTime of the route. Zero if travel times were not requested in MatrixAttributes.
+	TravelTime time.Duration
+	// DistanceMeters of the route. Zero if distances were not requested in MatrixAttributes.
+	DistanceMeters int32
+	// Err is a *MatrixCellError if the route could not be calculated, otherwise nil.
+	Err error
+}
+
+// Index returns the index of the route between origin i and destination j in the row-major TravelTimes,
+// Distances and ErrorCodes slices.
+func (m *MatrixResponse) Index(i, j int) int {
+	return i*m.NumDestinations + j
+}
+
+// Cell returns the route summary between origin i and destination j.
+// Cell panics if i or j is out of range.
+func (m *MatrixResponse) Cell(i, j int) MatrixCell {
+	if i < 0 || i >= m.NumOrigins || j < 0 || j >= m.NumDestinations {
+		panic(fmt.Sprintf("matrix cell (%d, %d) out of range %dx%d", i, j, m.NumOrigins, m.NumDestinations))
+	}
+	k := m.Index(i, j)
+	cell := MatrixCell{OriginIndex: i, DestinationIndex: j}
+	if k < len(m.TravelTimes) {
+		cell.TravelTime = time.Duration(m.TravelTimes[k]) * time.Second
+	}
+	if k < len(m.Distances) {
+		cell.DistanceMeters = m.Distances[k]
+	}
+	if k < len(m.ErrorCodes) && m.ErrorCodes[k] != ErrorCodeSuccess {
+		cell.Err = &MatrixCellError{OriginIndex: i, DestinationIndex: j, Code: m.ErrorCodes[k]}
+	}
+	return cell
+}
+
+// Cells returns all route summaries of the matrix, in row-major order.
+func (m *MatrixResponse) Cells() []MatrixCell {
+	cells := make([]MatrixCell, 0, m.NumOrigins*m.NumDestinations)
+	m.Range(func(cell MatrixCell) bool {
+		cells = append(cells, cell)
+		return true
+	})
+	return cells
+}
+
+// ReachableCells returns the route summaries of all routes that could be calculated, in row-major order.
+func (m *MatrixResponse) ReachableCells() []MatrixCell {
+	var cells []MatrixCell
+	m.Range(func(cell MatrixCell) bool {
+		if cell.Err == nil {
+			cells = append(cells, cell)
+		}
+		return true
+	})
+	return cells
+}
+
+// UnreachableCells returns the route summaries of all routes that could not be calculated, in row-major order.
+func (m *MatrixResponse) UnreachableCells() []MatrixCell {
+	var cells []MatrixCell
+	m.Range(func(cell MatrixCell) bool {
+		if cell.Err != nil {
+			cells = append(cells, cell)
+		}
+		return true
+	})
+	return cells
+}
+
+// Range calls f for every route summary of the matrix, in row-major order. Iteration stops if f returns false.
+func (m *MatrixResponse) Range(f func(cell MatrixCell) bool) {
+	for i := 0; i < m.NumOrigins; i++ {
+		for j := 0; j < m.NumDestinations; j++ {
+			if !f(m.Cell(i, j)) {
+				return
+			}
+		}
+	}
+}
+
+// RouteMatrixEntries converts the matrix to the entries of a routing v7 matrix response, so that code consuming
+// both API versions can share one model.
+// Routes that could not be calculated get the status routingv7.RouteStatusFailed and an empty summary.
+func (m *MatrixResponse) RouteMatrixEntries() []routingv7.RouteMatrixEntry {
+	entries := make([]routingv7.RouteMatrixEntry, 0, m.NumOrigins*m.NumDestinations)
+	m.Range(func(cell MatrixCell) bool {
+		entry := routingv7.RouteMatrixEntry{
+			StartIndex:       cell.OriginIndex,
+			DestinationIndex: cell.DestinationIndex,
+			Status:           routingv7.RouteStatusSuccess,
+		}
+		if cell.Err != nil {
+			entry.Status = routingv7.RouteStatusFailed
+		} else {
+			entry.Summary = routingv7.MatrixRouteSummary{
+				DistanceMeters: float64(cell.DistanceMeters),
+				TravelTime:     routingv7.Duration(cell.TravelTime.Seconds()),
+			}
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	return entries
+}
@@ -0,0 +1,110 @@
+package routingv8_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/routingv7"
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestMatrixResponse_Cell(t *testing.T) {
+	t.Parallel()
+	matrix := routingv8.MatrixResponse{
+		NumOrigins:      2,
+		NumDestinations: 2,
+		TravelTimes:     []int32{0, 60, 120, 0},
+		Distances:       []int32{0, 1000, 2000, 0},
+		ErrorCodes: routingv8.ErrorCodes{
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeSuccess,
+			routingv8.ErrorCodeDisconnected,
+		},
+	}
+	t.Run("reachable", func(t *testing.T) {
+		t.Parallel()
+		assert.DeepEqual(t, matrix.Cell(1, 0), routingv8.MatrixCell{
+			OriginIndex:      1,
+			DestinationIndex: 0,
+			TravelTime:       2 * time.Minute,
+			DistanceMeters:   2000,
+		})
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+		cell := matrix.Cell(1, 1)
+		var cellErr *routingv8.MatrixCellError
+		assert.Assert(t, errors.As(cell.Err, &cellErr))
+		assert.Equal(t, cellErr.Code, routingv8.ErrorCode(routingv8.ErrorCodeDisconnected))
+		assert.Error(t, cell.Err, "origin 1 to destination 1: disconnected (1)")
+	})
+	t.Run("classified", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			code     routingv8.ErrorCode
+			expected error
+		}{
+			{code: routingv8.ErrorCodeDisconnected, expected: routingv8.ErrNoRoute},
+			{code: routingv8.ErrorCodeMatchingFailed, expected: routingv8.ErrNoRoute},
+			{code: routingv8.ErrorCodeParameterViolation, expected: routingv8.ErrBadRequest},
+			{code: routingv8.ErrorCodeUnknown},
+		} {
+			tt := tt
+			t.Run(tt.code.String(), func(t *testing.T) {
+				t.Parallel()
+				err := &routingv8.MatrixCellError{Code: tt.code}
+				for _, target := range []error{routingv8.ErrNoRoute, routingv8.ErrBadRequest} {
+					assert.Equal(t, errors.Is(err, target), target == tt.expected, target.Error())
+				}
+			})
+		}
+	})
+	t.Run("reachable and unreachable cells", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, len(matrix.Cells()), 4)
+		assert.Equal(t, len(matrix.ReachableCells()), 3)
+		unreachable := matrix.UnreachableCells()
+		assert.Equal(t, len(unreachable), 1)
+		assert.Equal(t, unreachable[0].OriginIndex, 1)
+		assert.Equal(t, unreachable[0].DestinationIndex, 1)
+	})
+	t.Run("out of range", func(t *testing.T) {
+		t.Parallel()
+		defer func() {
+			assert.Assert(t, recover() != nil)
+		}()
+		matrix.Cell(2, 0)
+	})
+	t.Run("route matrix entries", func(t *testing.T) {
+		t.Parallel()
+		entries := matrix.RouteMatrixEntries()
+		assert.DeepEqual(t, entries, []routingv7.RouteMatrixEntry{
+			{StartIndex: 0, DestinationIndex: 0},
+			{
+				StartIndex:       0,
+				DestinationIndex: 1,
+				Summary:          routingv7.MatrixRouteSummary{DistanceMeters: 1000, TravelTime: 60},
+			},
+			{
+				StartIndex:       1,
+				DestinationIndex: 0,
+				Summary:          routingv7.MatrixRouteSummary{DistanceMeters: 2000, TravelTime: 120},
+			},
+			{StartIndex: 1, DestinationIndex: 1, Status: routingv7.RouteStatusFailed},
+		})
+	})
+}
+
+func TestMatrixResponse_Cell_WithoutErrorCodes(t *testing.T) {
+	t.Parallel()
+	matrix := routingv8.MatrixResponse{
+		NumOrigins:      1,
+		NumDestinations: 2,
+		Distances:       []int32{10, 20},
+	}
+	assert.DeepEqual(t, matrix.Cell(0, 1), routingv8.MatrixCell{DestinationIndex: 1, DistanceMeters: 20})
+	assert.Equal(t, len(matrix.UnreachableCells()), 0)
+}
@@ -0,0 +1,254 @@
+package routingv8
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Flexible Polyline format version supported by this package.
+const polylineFormatVersion = 1
+
+// DefaultPolylinePrecision is the number of decimal digits used by the HERE APIs for latitude and longitude.
+const DefaultPolylinePrecision = 5
+
+const polylineEncodingTable = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// ThirdDimension describes the meaning of the optional third value of each point in a Flexible Polyline.
+type ThirdDimension int
+
+const (
+	// ThirdDimensionAbsent means that the polyline only contains latitude and longitude.
+	ThirdDimensionAbsent ThirdDimension = 0
+	// ThirdDimensionLevel is the floor level, e.g. inside a building.
+	ThirdDimensionLevel ThirdDimension = 1
+	// ThirdDimensionAltitude is the height above the WGS84 ellipsoid, in meters.
+	ThirdDimensionAltitude ThirdDimension = 2
+	// ThirdDimensionElevation is the height above mean sea level, in meters.
+	ThirdDimensionElevation ThirdDimension = 3
+	// ThirdDimensionCustom1 is reserved for custom values.
+	ThirdDimensionCustom1 ThirdDimension = 6
+	// ThirdDimensionCustom2 is reserved for custom values.
+	ThirdDimensionCustom2 ThirdDimension = 7
+)
+
+func (t *ThirdDimension) String() string {
+	switch *t {
+	case ThirdDimensionAbsent:
+		return "absent"
+	case ThirdDimensionLevel:
+		return "level"
+	case ThirdDimensionAltitude:
+		return "altitude"
+	case ThirdDimensionElevation:
+		return "elevation"
+	case ThirdDimensionCustom1:
+		return "custom1"
+	case ThirdDimensionCustom2:
+		return "custom2"
+	default:
+		return invalid
+	}
+}
+
+// PolylineHeader describes how the points of a Flexible Polyline are encoded.
+type PolylineHeader struct {
+	// Precision is the number of decimal digits of latitude and longitude. Range: [0-15].
+	Precision int
+	// ThirdDimension is the type of the third dimension, if any.
+	ThirdDimension ThirdDimension
+	// ThirdDimensionPrecision is the number of decimal digits of the third dimension. Range: [0-15].
+	ThirdDimensionPrecision int
+}
+
+func (h PolylineHeader) validate() error {
+	if h.Precision < 0 || h.Precision > 15 {
+		return fmt.Errorf("precision %d out of range [0-15]", h.Precision)
+	}
+	if h.ThirdDimensionPrecision < 0 || h.ThirdDimensionPrecision > 15 {
+		return fmt.Errorf("third dimension precision %d out of range [0-15]", h.ThirdDimensionPrecision)
+	}
+	if h.ThirdDimension.String() == invalid {
+		return fmt.Errorf("invalid third dimension %d", h.ThirdDimension)
+	}
+	return nil
+}
+
+// Decode returns the waypoints of the polyline.
+// The third dimension, if present, is stored in the Elevation field of each waypoint regardless of its type.
+func (p Polyline) Decode() ([]GeoWaypoint, error) {
+	waypoints, _, err := DecodePolyline(p)
+	return waypoints, err
+}
+
+// Header returns the header of the polyline, without decoding its points.
+func (p Polyline) Header() (PolylineHeader, error) {
+	d := polylineDecoder{s: string(p)}
+	return d.header()
+}
+
+// DecodePolyline decodes a Flexible Polyline into its waypoints and header.
+// The third dimension, if present, is stored in the Elevation field of each waypoint regardless of its type.
+// See https://github.com/heremaps/flexible-polyline for details about the format.
+func DecodePolyline(p Polyline) (_ []GeoWaypoint, _ PolylineHeader, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("decode polyline: %w", err)
+		}
+	}()
+	d := polylineDecoder{s: string(p)}
+	header, err := d.header()
+	if err != nil {
+		return nil, PolylineHeader{}, err
+	}
+	multiplier := math.Pow10(header.Precision)
+	thirdDimensionMultiplier := math.Pow10(header.ThirdDimensionPrecision)
+	var waypoints []GeoWaypoint
+	var lat, lng, z int64
+	for !d.done() {
+		deltaLat, err := d.signed()
+		if err != nil {
+			return nil, PolylineHeader{}, err
+		}
+		deltaLng, err := d.signed()
+		if err != nil {
+			return nil, PolylineHeader{}, err
+		}
+		lat += deltaLat
+		lng += deltaLng
+		waypoint := GeoWaypoint{
+			Lat:  float64(lat) / multiplier,
+			Long: float64(lng) / multiplier,
+		}
+		if header.ThirdDimension != ThirdDimensionAbsent {
+			deltaZ, err := d.signed()
+			if err != nil {
+				return nil, PolylineHeader{}, err
+			}
+			z += deltaZ
+			waypoint.Elevation = float64(z) / thirdDimensionMultiplier
+		}
+		waypoints = append(waypoints, waypoint)
+	}
+	return waypoints, header, nil
+}
+
+// EncodePolyline encodes waypoints as a Flexible Polyline using the provided header.
+// When the header has a third dimension, it is read from the Elevation field of each waypoint.
+// See https://github.com/heremaps/flexible-polyline for details about the format.
+func EncodePolyline(waypoints []GeoWaypoint, header PolylineHeader) (_ Polyline, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("encode polyline: %w", err)
+		}
+	}()
+	if err := header.validate(); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	encodeUnsignedPolylineValue(&b, polylineFormatVersion)
+	encodeUnsignedPolylineValue(
+		&b,
+		uint64(header.Precision)|uint64(header.ThirdDimension)<<4|uint64(header.ThirdDimensionPrecision)<<7,
+	)
+	multiplier := math.Pow10(header.Precision)
+	thirdDimensionMultiplier := math.Pow10(header.ThirdDimensionPrecision)
+	var lastLat, lastLng, lastZ int64
+	for _, waypoint := range waypoints {
+		lat := int64(math.Round(waypoint.Lat * multiplier))
+		lng := int64(math.Round(waypoint.Long * multiplier))
+		encodeSignedPolylineValue(&b, lat-lastLat)
+		encodeSignedPolylineValue(&b, lng-lastLng)
+		lastLat, lastLng = lat, lng
+		if header.ThirdDimension != ThirdDimensionAbsent {
+			z := int64(math.Round(waypoint.Elevation * thirdDimensionMultiplier))
+			encodeSignedPolylineValue(&b, z-lastZ)
+			lastZ = z
+		}
+	}
+	return Polyline(b.String()), nil
+}
+
+func encodeUnsignedPolylineValue(b *strings.Builder, value uint64) {
+	for value > 0x1f {
+		b.WriteByte(polylineEncodingTable[(value&0x1f)|0x20])
+		value >>= 5
+	}
+	b.WriteByte(polylineEncodingTable[value])
+}
+
+func encodeSignedPolylineValue(b *strings.Builder, value int64) {
+	unsigned := uint64(value) << 1
+	if value < 0 {
+		unsigned = ^unsigned
+	}
+	encodeUnsignedPolylineValue(b, unsigned)
+}
+
+type polylineDecoder struct {
+	s   string
+	pos int
+}
+
+func (d *polylineDecoder) done() bool {
+	return d.pos >= len(d.s)
+}
+
+func (d *polylineDecoder) header() (PolylineHeader, error) {
+	version, err := d.unsigned()
+	if err != nil {
+		return PolylineHeader{}, err
+	}
+	if version != polylineFormatVersion {
+		return PolylineHeader{}, fmt.Errorf("unsupported format version %d", version)
+	}
+	content, err := d.unsigned()
+	if err != nil {
+		return PolylineHeader{}, err
+	}
+	header := PolylineHeader{
+		Precision:               int(content & 0xf),
+		ThirdDimension:          ThirdDimension((content >> 4) & 0x7),
+		ThirdDimensionPrecision: int((content >> 7) & 0xf),
+	}
+	if err := header.validate(); err != nil {
+		return PolylineHeader{}, err
+	}
+	return header, nil
+}
+
+func (d *polylineDecoder) unsigned() (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		if d.done() {
+			return 0, errors.New("unexpected end of polyline")
+		}
+		value := strings.IndexByte(polylineEncodingTable, d.s[d.pos])
+		if value < 0 {
+			return 0, fmt.Errorf("invalid character %q at position %d", d.s[d.pos], d.pos)
+		}
+		d.pos++
+		if shift > 60 {
+			return 0, errors.New("value overflow")
+		}
+		result |= uint64(value&0x1f) << shift
+		if value&0x20 == 0 {
+			return result, nil
+		}
+		shift += 5
+	}
+}
+
+func (d *polylineDecoder) signed() (int64, error) {
+	unsigned, err := d.unsigned()
+	if err != nil {
+		return 0, err
+	}
+	value := int64(unsigned >> 1)
+	if unsigned&1 != 0 {
+		value = ^value
+	}
+	return value, nil
+}
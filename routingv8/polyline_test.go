@@ -0,0 +1,145 @@
+package routingv8_test
+
+import (
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+func TestDecodePolyline(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name           string
+		polyline       routingv8.Polyline
+		expected       []routingv8.GeoWaypoint
+		expectedHeader routingv8.PolylineHeader
+		errStr         string
+	}{
+		{
+			name:     "two dimensions",
+			polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y",
+			expected: []routingv8.GeoWaypoint{
+				{Lat: 50.10228, Long: 8.69821},
+				{Lat: 50.10201, Long: 8.69567},
+				{Lat: 50.10063, Long: 8.6915},
+				{Lat: 50.09878, Long: 8.68752},
+			},
+			expectedHeader: routingv8.PolylineHeader{Precision: 5},
+		},
+		{
+			name:     "third dimension",
+			polyline: "BlBoz5xJ67i1BU1B7PUzIhaUxL7YU",
+			expected: []routingv8.GeoWaypoint{
+				{Lat: 50.10228, Long: 8.69821, Elevation: 10},
+				{Lat: 50.10201, Long: 8.69567, Elevation: 20},
+				{Lat: 50.10063, Long: 8.6915, Elevation: 30},
+				{Lat: 50.09878, Long: 8.68752, Elevation: 40},
+			},
+			expectedHeader: routingv8.PolylineHeader{
+				Precision:      5,
+				ThirdDimension: routingv8.ThirdDimensionAltitude,
+			},
+		},
+		{
+			name:     "unsupported version",
+			polyline: "CFoz5xJ67i1B",
+			errStr:   "unsupported format version 2",
+		},
+		{
+			name:     "invalid character",
+			polyline: "BFoz5xJ!",
+			errStr:   "invalid character",
+		},
+		{
+			name:     "truncated",
+			polyline: "BFoz5xJ67i1B1",
+			errStr:   "unexpected end of polyline",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, header, err := routingv8.DecodePolyline(tt.polyline)
+			if tt.errStr != "" {
+				assert.ErrorContains(t, err, tt.errStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, header, tt.expectedHeader)
+			assert.Equal(t, len(got), len(tt.expected))
+			for i := range got {
+				assert.Assert(t, closeTo(got[i].Lat, tt.expected[i].Lat))
+				assert.Assert(t, closeTo(got[i].Long, tt.expected[i].Long))
+				assert.Assert(t, closeTo(got[i].Elevation, tt.expected[i].Elevation))
+			}
+		})
+	}
+}
+
+func TestEncodePolyline(t *testing.T) {
+	t.Parallel()
+	waypoints := []routingv8.GeoWaypoint{
+		{Lat: 50.1022829, Long: 8.6982122, Elevation: 10},
+		{Lat: 50.1020076, Long: 8.6956695, Elevation: 20},
+		{Lat: 50.1006313, Long: 8.6914960, Elevation: 30},
+		{Lat: 50.0987800, Long: 8.6875156, Elevation: 40},
+	}
+	t.Run("two dimensions", func(t *testing.T) {
+		t.Parallel()
+		got, err := routingv8.EncodePolyline(waypoints, routingv8.PolylineHeader{
+			Precision: routingv8.DefaultPolylinePrecision,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, got, routingv8.Polyline("BFoz5xJ67i1B1B7PzIhaxL7Y"))
+	})
+	t.Run("third dimension", func(t *testing.T) {
+		t.Parallel()
+		got, err := routingv8.EncodePolyline(waypoints, routingv8.PolylineHeader{
+			Precision:      routingv8.DefaultPolylinePrecision,
+			ThirdDimension: routingv8.ThirdDimensionAltitude,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, got, routingv8.Polyline("BlBoz5xJ67i1BU1B7PUzIhaUxL7YU"))
+	})
+	t.Run("invalid precision", func(t *testing.T) {
+		t.Parallel()
+		_, err := routingv8.EncodePolyline(waypoints, routingv8.PolylineHeader{Precision: 16})
+		assert.ErrorContains(t, err, "precision 16 out of range")
+	})
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		header := routingv8.PolylineHeader{
+			Precision:               7,
+			ThirdDimension:          routingv8.ThirdDimensionElevation,
+			ThirdDimensionPrecision: 2,
+		}
+		encoded, err := routingv8.EncodePolyline(waypoints, header)
+		assert.NilError(t, err)
+		decoded, gotHeader, err := routingv8.DecodePolyline(encoded)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, gotHeader, header)
+		assert.DeepEqual(t, decoded, waypoints)
+	})
+}
+
+func TestPolyline_Decode(t *testing.T) {
+	t.Parallel()
+	polyline := routingv8.Polyline(
+		"BGwynmkDu39wZvBtFAA3InfAAvHrdAAvHvbAAoGzF0FnGoGvHsOvRAA8L3NAAkSnVAAo" +
+			"GjIsEzFAAgFvHkDrJAAwHrJoVvb0ezoBAAjInVAA3N_iBAAzJ_Z",
+	)
+	got, err := polyline.Decode()
+	assert.NilError(t, err)
+	assert.Assert(t, len(got) > 0)
+	// Matches the departure and arrival places of testdata/route-polyline.json.
+	assert.Assert(t, closeTo(got[0].Lat, 52.53098))
+	assert.Assert(t, closeTo(got[0].Long, 13.38457))
+	assert.Assert(t, closeTo(got[len(got)-1].Lat, 52.53233))
+	assert.Assert(t, closeTo(got[len(got)-1].Long, 13.37887))
+}
+
+func closeTo(a, b float64) bool {
+	const epsilon = 1e-5
+	return a-b < epsilon && b-a < epsilon
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 const (
@@ -40,28 +41,190 @@ type CalculateMatrixBody struct {
 
 type CalculateMatrixRequest struct {
 	// Async flag requires the Client to poll the calculation results and finally requesting to download
-	// the calculation results.
+	// the calculation results. Use MatrixService.CalculateMatrixAsync and MatrixService.WaitForMatrix for that.
 	Async Async
 	// Body to pass to request to Here Maps API
 	Body *CalculateMatrixBody
 }
 
 type RoutesRequest struct {
-	Origin        GeoWaypoint
-	Destination   GeoWaypoint
+	Origin      GeoWaypoint
+	Destination GeoWaypoint
+	// Via waypoints the route must pass, in order, between Origin and Destination.
+	// Each via that is not a pass-through waypoint ends a Section of the route. The Place of the section
+	// boundary refers to the via through its Waypoint index, where 0 is the origin and i is Via[i-1].
+	Via           []Via
 	TransportMode TransportMode
 	AvoidAreas    []AreaFeature
+	// AvoidGeoAreas are geographic areas that routes will avoid going through.
+	AvoidGeoAreas []AvoidArea
+	// AvoidSegments are references of road segments that routes will avoid, e.g. here:cm:segment:76771992#+.
+	AvoidSegments []string
+	// AvoidZoneCategories are categories of zones that routes will avoid, e.g. environmental zones.
+	AvoidZoneCategories []ZoneCategory
+	// ExcludeCountries that routes must not pass through, as ISO 3166-1 alpha-3 country codes.
+	ExcludeCountries []string
 	// Which attributes to return in the response.
 	// If not specified defaults to SummaryReturnAttribute.
 	Return []ReturnAttribute
 	// The time of departure.
 	// If not specified the current time is used.
 	// To not take time into account use DepartureTimeAny.
+	// Can not be combined with ArrivalTime.
 	DepartureTime string
+	// The time of arrival, in RFC 3339 format.
+	// Routes are calculated backwards from the destination so that they arrive at the given time.
+	// Can not be combined with DepartureTime.
+	ArrivalTime string
+	// RoutingMode optimization. If not specified defaults to RoutingModeFast.
+	RoutingMode RoutingMode
+	// Alternatives is the number of alternative routes to return in addition to the main route. Range: [0-6].
+	// Alternatives are returned in order after the main route in RoutesResponse.Routes.
+	Alternatives int
 	// Spans define which content attributes that are included in the response spans
 	Spans []SpanAttribute
 	// Vehicle-specific parameters.
 	Vehicle *Vehicle
+	// Lang is the preferred languages of instructions, as BCP47 language codes in order of preference.
+	// If not specified defaults to en-US.
+	Lang []string
+	// Tolls configures the calculation of toll costs. Requires TollsReturnAttribute.
+	Tolls *TollOptions
+	// EV configures electric vehicle routing, including energy consumption and charging stops.
+	EV *EV
+}
+
+// EV describes the energy consumption and battery of an electric vehicle.
+// See https://www.here.com/docs/bundle/routing-api-developer-guide-v8/page/tutorials/ev-routing.html
+// for details.
+type EV struct {
+	// FreeFlowSpeedTable is the consumption curve of the vehicle at free-flow speeds. Required.
+	FreeFlowSpeedTable []SpeedConsumption
+	// TrafficSpeedTable is the consumption curve of the vehicle in traffic.
+	// If not specified FreeFlowSpeedTable is used.
+	TrafficSpeedTable []SpeedConsumption
+	// AuxiliaryConsumption is the consumption of auxiliary systems, e.g. heating, in kWh per second.
+	AuxiliaryConsumption float64
+	// Ascent is the additional consumption when driving uphill, in kWh per meter of elevation gain.
+	Ascent float64
+	// Descent is the energy recovered when driving downhill, in kWh per meter of elevation loss.
+	Descent float64
+	// InitialCharge of the battery at departure, in kWh.
+	InitialCharge float64
+	// MaxCharge is the capacity of the battery, in kWh.
+	MaxCharge float64
+	// ChargingCurve is the charging power of the vehicle at different states of charge.
+	ChargingCurve []ChargingCurvePoint
+	// MaxChargingVoltage supported by the vehicle, in volts.
+	MaxChargingVoltage float64
+	// MaxChargeAfterChargingStation is the charge to stop charging at, in kWh.
+	MaxChargeAfterChargingStation float64
+	// MinChargeAtChargingStation is the minimum charge when arriving at a charging station, in kWh.
+	MinChargeAtChargingStation float64
+	// MinChargeAtDestination is the minimum charge when arriving at the destination, in kWh.
+	MinChargeAtDestination float64
+	// ConnectorTypes supported by the vehicle.
+	ConnectorTypes []ConnectorType
+	// MakeReachable adds charging stops to the route so that the destination can be reached.
+	// Requires InitialCharge, MaxCharge, ChargingCurve and ConnectorTypes.
+	MakeReachable bool
+}
+
+// SpeedConsumption is a point of a consumption curve.
+type SpeedConsumption struct {
+	// Speed in km/h.
+	Speed float64
+	// Consumption at Speed in kWh per km.
+	Consumption float64
+}
+
+// ChargingCurvePoint is a point of a charging curve.
+type ChargingCurvePoint struct {
+	// Charge is the state of charge of the battery, in kWh.
+	Charge float64
+	// Power is the charging power at Charge, in kW.
+	Power float64
+}
+
+type ConnectorType string
+
+const (
+	ConnectorTypeIEC62196Type1Combo ConnectorType = "iec62196Type1Combo"
+	ConnectorTypeIEC62196Type2Combo ConnectorType = "iec62196Type2Combo"
+	ConnectorTypeChademo            ConnectorType = "Chademo"
+	ConnectorTypeTesla              ConnectorType = "Tesla"
+	ConnectorTypeGBTDC              ConnectorType = "gbtDc"
+)
+
+// TollOptions configures the calculation of toll costs.
+type TollOptions struct {
+	// Currency to convert toll costs to, as an ISO 4217 currency code, e.g. EUR.
+	// The converted costs are returned in TollFare.ConvertedPrice.
+	Currency string
+	// Transponders the vehicle is equipped with. Tolls payable by transponder are then included.
+	Transponders TollTransponders
+	// Vignettes enables the calculation of vignettes, i.e. time-based tolls.
+	Vignettes bool
+	// EmissionType of the vehicle, used for emission-based tolls.
+	EmissionType *TollEmissionType
+	// Summaries to include in the response.
+	Summaries []TollSummary
+}
+
+type TollTransponders string
+
+const (
+	// TollTranspondersAll means that the vehicle has all transponders needed to pay tolls.
+	TollTranspondersAll TollTransponders = "all"
+)
+
+// TollEmissionType is the emission standard and CO2 emission class of the vehicle.
+type TollEmissionType struct {
+	// Standard is the emission standard of the vehicle.
+	Standard EmissionStandard
+	// CO2Class is the CO2 emission class of the vehicle. Range: [1-5]. Zero if unspecified.
+	CO2Class int
+}
+
+type EmissionStandard string
+
+const (
+	EmissionStandardEuro1   EmissionStandard = "euro1"
+	EmissionStandardEuro2   EmissionStandard = "euro2"
+	EmissionStandardEuro3   EmissionStandard = "euro3"
+	EmissionStandardEuro4   EmissionStandard = "euro4"
+	EmissionStandardEuro5   EmissionStandard = "euro5"
+	EmissionStandardEuro6   EmissionStandard = "euro6"
+	EmissionStandardEuroEEV EmissionStandard = "euroEev"
+)
+
+type TollSummary string
+
+const (
+	// TollSummaryTotal summarizes the toll costs of the whole section.
+	TollSummaryTotal TollSummary = "total"
+	// TollSummaryCountry summarizes the toll costs per country.
+	TollSummaryCountry TollSummary = "country"
+	// TollSummaryTollSystem summarizes the toll costs per toll system.
+	TollSummaryTollSystem TollSummary = "tollSystem"
+)
+
+// Via is a waypoint that a route must pass between its origin and destination.
+type Via struct {
+	// Location of the waypoint.
+	Location GeoWaypoint
+	// StopDuration is the time spent at the waypoint. Rounded down to whole seconds.
+	// Not allowed for pass-through waypoints.
+	StopDuration time.Duration
+	// PassThrough waypoints do not split the route into sections, and do not allow u-turns.
+	PassThrough bool
+	// Radius in meters around Location within which the route may pass the waypoint.
+	Radius int
+	// Course is the desired direction at the waypoint, in degrees clockwise from north. Range: [0-360).
+	// Nil if unspecified.
+	Course *int
+	// NameHint is the name of the street the waypoint should be matched to.
+	NameHint string
 }
 
 type ReturnAttribute string
@@ -70,6 +233,16 @@ const (
 	PolylineReturnAttribute  ReturnAttribute = "polyline"
 	SummaryReturnAttribute   ReturnAttribute = "summary"
 	ElevationReturnAttribute ReturnAttribute = "elevation"
+	// ActionsReturnAttribute returns the actions, e.g. turns, of each section.
+	ActionsReturnAttribute ReturnAttribute = "actions"
+	// InstructionsReturnAttribute adds human-readable instructions to the actions.
+	// Requires ActionsReturnAttribute, and uses the language given by Lang.
+	InstructionsReturnAttribute ReturnAttribute = "instructions"
+	// TurnByTurnActionsReturnAttribute returns the actions of each section, with details about the current
+	// and next road, as needed for turn-by-turn guidance.
+	TurnByTurnActionsReturnAttribute ReturnAttribute = "turnByTurnActions"
+	// TollsReturnAttribute returns the tolls to pay along each section. See RoutesRequest.Tolls for options.
+	TollsReturnAttribute ReturnAttribute = "tolls"
 )
 
 type GeoWaypoint struct {
@@ -260,6 +433,26 @@ func (m *MatrixAttribute) String() string {
 	}
 }
 
+func (m *MatrixAttribute) UnmarshalString(value string) error {
+	switch value {
+	case "travelTimes":
+		*m = MatrixAttributeTravelTimes
+	case "distances":
+		*m = MatrixAttributeDistances
+	default:
+		return fmt.Errorf("invalid matrix attribute")
+	}
+	return nil
+}
+
+func (m *MatrixAttribute) UnmarshalJSON(b []byte) error {
+	value, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return m.UnmarshalString(value)
+}
+
 type MatrixAttributes []MatrixAttribute
 
 func (m *MatrixAttributes) MarshalJSON() ([]byte, error) {
@@ -295,10 +488,30 @@ func (r *RoutingMode) String() string {
 	}
 }
 
+func (r *RoutingMode) UnmarshalString(value string) error {
+	switch value {
+	case "fast":
+		*r = RoutingModeFast
+	case "short":
+		*r = RoutingModeShort
+	default:
+		return fmt.Errorf("invalid routing mode")
+	}
+	return nil
+}
+
 func (r *RoutingMode) MarshalJSON() ([]byte, error) {
 	return []byte(strconv.Quote(r.String())), nil
 }
 
+func (r *RoutingMode) UnmarshalJSON(b []byte) error {
+	value, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return r.UnmarshalString(value)
+}
+
 type TransportMode int
 
 const (
@@ -332,6 +545,26 @@ func (t *TransportMode) String() string {
 	}
 }
 
+func (t *TransportMode) UnmarshalString(value string) error {
+	switch value {
+	case "car":
+		*t = TransportModeCar
+	case "truck":
+		*t = TransportModeTruck
+	case "pedestrian":
+		*t = TransportModePedestrian
+	case "bicycle":
+		*t = TransportModeBicycle
+	case "taxi":
+		*t = TransportModeTaxi
+	case "scooter":
+		*t = TransportModeScooter
+	default:
+		return fmt.Errorf("invalid transport mode")
+	}
+	return nil
+}
+
 func (t *TransportMode) MarshalJSON() ([]byte, error) {
 	buffer := bytes.NewBufferString(`"`)
 	buffer.WriteString(t.String())
@@ -339,6 +572,14 @@ func (t *TransportMode) MarshalJSON() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (t *TransportMode) UnmarshalJSON(b []byte) error {
+	value, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalString(value)
+}
+
 type ShippedHazardousGoods int
 
 const (
@@ -488,6 +729,45 @@ func (t *AreaFeature) MarshalJSON() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// AvoidArea is a geographic area that routes avoid. Exactly one of BoundingBox, Polygon and Corridor must be set.
+type AvoidArea struct {
+	// BoundingBox of the area.
+	BoundingBox *BoundingBox
+	// Polygon of the area, with at least 3 vertices.
+	Polygon []GeoWaypoint
+	// Corridor of the area.
+	Corridor *Corridor
+	// Exceptions are parts of the area that routes may pass through. Exceptions cannot have exceptions.
+	Exceptions []AvoidArea
+}
+
+// BoundingBox is a rectangular area, in degrees.
+type BoundingBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// Corridor is the area within a radius of a polyline.
+type Corridor struct {
+	// Polyline along the corridor. Use EncodePolyline to create one from waypoints.
+	Polyline Polyline
+	// Radius of the corridor, in meters.
+	Radius int
+}
+
+type ZoneCategory string
+
+const (
+	// ZoneCategoryEnvironmental are environmental zones, e.g. low emission zones.
+	ZoneCategoryEnvironmental ZoneCategory = "environmental"
+	// ZoneCategoryVignette are zones that require a vignette.
+	ZoneCategoryVignette ZoneCategory = "vignette"
+	// ZoneCategoryCongestionPricing are zones with congestion charges.
+	ZoneCategoryCongestionPricing ZoneCategory = "congestionPricing"
+)
+
 type SpanAttribute string
 
 // For available span attributes to implementation see:
@@ -519,13 +799,25 @@ type RouteImportRequest struct {
 	// The time of departure.
 	// If not specified the current time is used.
 	// To not take time into account use DepartureTimeAny.
+	// Can not be combined with ArrivalTime.
 	DepartureTime string
+	// The time of arrival, in RFC 3339 format.
+	// Can not be combined with DepartureTime.
+	ArrivalTime string
+	// RoutingMode optimization. If not specified defaults to RoutingModeFast.
+	RoutingMode RoutingMode
+	// Alternatives is the number of alternative routes to return in addition to the main route. Range: [0-6].
+	// Alternatives are returned in order after the main route in RoutesResponse.Routes.
+	Alternatives int
 	// Spans define which content attributes that are included in the response spans
 	Spans []SpanAttribute
 	// An array of GPS coordinates
 	Trace []GeoWaypoint
 	// Vehicle-specific parameters.
 	Vehicle *Vehicle
+	// Lang is the preferred languages of instructions, as BCP47 language codes in order of preference.
+	// If not specified defaults to en-US.
+	Lang []string
 }
 
 type RouteImportRequestBody struct {
@@ -562,8 +854,18 @@ type Vehicle struct {
 	Length int
 	// Specifies the type of the vehicle. Limitations: only valid for transportMode=truck.
 	Type VehicleType
+	// Category of the vehicle, used to calculate tolls for special vehicle categories.
+	Category VehicleCategory
 }
 
+type VehicleCategory string
+
+const (
+	// VehicleCategoryLightTruck is a truck with a gross weight below 3.5 tonnes, tolled like a car in most
+	// countries.
+	VehicleCategoryLightTruck VehicleCategory = "lightTruck"
+)
+
 type VehicleType string
 
 const (
@@ -584,3 +886,30 @@ func (t *VehicleType) String() string {
 		return invalid
 	}
 }
+
+type MatrixStatusRequest struct {
+	// MatrixID of the asynchronous matrix calculation, as returned by CalculateMatrixAsync.
+	MatrixID string
+}
+
+type WaitForMatrixRequest struct {
+	// MatrixID of the asynchronous matrix calculation, as returned by CalculateMatrixAsync.
+	MatrixID string
+	// PollInterval is the initial wait between two status requests. Defaults to 1 second.
+	// The interval is doubled after every poll until it reaches MaxPollInterval.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum wait between two status requests. Defaults to 30 seconds.
+	MaxPollInterval time.Duration
+}
+
+type CalculateTiledMatrixRequest struct {
+	// Request to split into tiles. Each tile is calculated with the same parameters as the request, but with a
+	// subset of the origins and destinations. When Async is set, each tile is calculated asynchronously.
+	Request *CalculateMatrixRequest
+	// MaxOriginsPerTile is the maximum number of origins in each tile. Defaults to 15.
+	MaxOriginsPerTile int
+	// MaxDestinationsPerTile is the maximum number of destinations in each tile. Defaults to 100.
+	MaxDestinationsPerTile int
+	// MaxConcurrency is the maximum number of tiles calculated at the same time. Defaults to 4.
+	MaxConcurrency int
+}
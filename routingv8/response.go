@@ -3,6 +3,7 @@ package routingv8
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type ErrorCodes []ErrorCode
@@ -56,6 +57,35 @@ type CalculateMatrixResponse struct {
 	RegionDefinition RegionDefinition `json:"regionDefinition"`
 }
 
+// MatrixStatusResponse describes the state of an asynchronous matrix calculation.
+type MatrixStatusResponse struct {
+	// MatrixID is unique identifier of the matrix
+	MatrixID string `json:"matrixId"`
+	// Status of the matrix calculation.
+	Status MatrixStatus `json:"status"`
+	// StatusURL to poll for the status of the calculation.
+	StatusURL string `json:"statusUrl,omitempty"`
+	// ResultURL to download the result from. Only set when the calculation is completed.
+	ResultURL string `json:"resultUrl,omitempty"`
+	// Error that caused the calculation to fail. Only set when the status is failed.
+	Error *HereErrorResponse `json:"error,omitempty"`
+}
+
+type MatrixStatus string
+
+const (
+	// MatrixStatusAccepted indicates that the calculation has been accepted but not yet started.
+	MatrixStatusAccepted MatrixStatus = "accepted"
+	// MatrixStatusInProgress indicates that the calculation is ongoing.
+	MatrixStatusInProgress MatrixStatus = "inProgress"
+	// MatrixStatusCompleted indicates that the result is ready to be downloaded.
+	MatrixStatusCompleted MatrixStatus = "completed"
+	// MatrixStatusTimedOut indicates that the calculation took too long and was aborted.
+	MatrixStatusTimedOut MatrixStatus = "timedOut"
+	// MatrixStatusFailed indicates that the calculation failed.
+	MatrixStatusFailed MatrixStatus = "failed"
+)
+
 // RoutesResponse contains the possible routes.
 type RoutesResponse struct {
 	// Routes in the possible routes between the origin and target.
@@ -149,6 +179,259 @@ type Section struct {
 	Notices []VehicleNotice `json:"notices"`
 	// Spans attached to a `Section` describing vehicle content.
 	Spans []Span `json:"spans"`
+	// Actions to follow along the section. Returned with ActionsReturnAttribute.
+	Actions []Action `json:"actions,omitempty"`
+	// TurnByTurnActions to follow along the section. Returned with TurnByTurnActionsReturnAttribute.
+	TurnByTurnActions []Action `json:"turnByTurnActions,omitempty"`
+	// Tolls to pay along the section. Returned with TollsReturnAttribute.
+	Tolls []Toll `json:"tolls,omitempty"`
+	// TollSystems referenced by the tolls of the section. Returned with TollsReturnAttribute.
+	TollSystems []TollSystem `json:"tollSystems,omitempty"`
+	// PostActions to perform after arriving at the end of the section, e.g. charging at a charging station.
+	PostActions []PostAction `json:"postActions,omitempty"`
+}
+
+// PostAction is an action to perform at the end of a section, e.g. charging an electric vehicle.
+type PostAction struct {
+	// Action type, e.g. PostActionTypeCharging.
+	Action PostActionType `json:"action"`
+	// Duration of the action, in seconds.
+	Duration int32 `json:"duration"`
+	// ConsumablePower is the power available for charging, in kW.
+	ConsumablePower float64 `json:"consumablePower,omitempty"`
+	// ArrivalCharge is the state of charge when the charging starts, in kWh.
+	ArrivalCharge float64 `json:"arrivalCharge,omitempty"`
+	// TargetCharge is the state of charge when the charging ends, in kWh.
+	TargetCharge float64 `json:"targetCharge,omitempty"`
+}
+
+type PostActionType string
+
+const (
+	// PostActionTypeChargingSetup is the time needed to set up charging, e.g. to plug in the vehicle.
+	PostActionTypeChargingSetup PostActionType = "chargingSetup"
+	// PostActionTypeCharging is the time spent charging the vehicle.
+	PostActionTypeCharging PostActionType = "charging"
+	// PostActionTypeWait is the time spent waiting, e.g. for a stop duration.
+	PostActionTypeWait PostActionType = "wait"
+)
+
+// ChargingStops returns the charging stops along the route, in order.
+func (r *Route) ChargingStops() []ChargingStop {
+	var stops []ChargingStop
+	for i, section := range r.Sections {
+		if section.Arrival.Place.Type != PlaceTypeChargingStation {
+			continue
+		}
+		stop := ChargingStop{
+			Place:         section.Arrival.Place,
+			ArrivalCharge: section.Arrival.Charge,
+		}
+		if i+1 < len(r.Sections) {
+			stop.DepartureCharge = r.Sections[i+1].Departure.Charge
+		}
+		for _, action := range section.PostActions {
+			stop.Duration += time.Duration(action.Duration) * time.Second
+			if action.Action == PostActionTypeCharging {
+				stop.ChargingDuration += time.Duration(action.Duration) * time.Second
+			}
+		}
+		stops = append(stops, stop)
+	}
+	return stops
+}
+
+// ChargingStop is a stop at a charging station along a route.
+type ChargingStop struct {
+	// Place of the charging station.
+	Place Place
+	// ArrivalCharge is the state of charge when arriving at the charging station, in kWh.
+	ArrivalCharge *float64
+	// DepartureCharge is the state of charge when departing from the charging station, in kWh.
+	DepartureCharge *float64
+	// Duration of the stop, including setup and charging.
+	Duration time.Duration
+	// ChargingDuration is the time spent charging.
+	ChargingDuration time.Duration
+}
+
+// Toll is a toll to pay along a section.
+type Toll struct {
+	// CountryCode of the toll, as an ISO 3166-1 alpha-3 country code.
+	CountryCode string `json:"countryCode,omitempty"`
+	// TollSystemRef is the index of the toll system of the toll in Section.TollSystems.
+	TollSystemRef int `json:"tollSystemRef"`
+	// TollSystem is the name of the toll system collecting the toll.
+	TollSystem string `json:"tollSystem,omitempty"`
+	// Fares that can be paid for the toll, e.g. one per payment method. Only one of the fares has to be paid.
+	Fares []TollFare `json:"fares,omitempty"`
+	// TollCollectionLocations where the toll is collected.
+	TollCollectionLocations []TollCollectionLocation `json:"tollCollectionLocations,omitempty"`
+}
+
+// TollFare is a price that can be paid for a toll.
+type TollFare struct {
+	// ID of the fare.
+	ID string `json:"id,omitempty"`
+	// Name of the fare.
+	Name string `json:"name,omitempty"`
+	// Price of the fare, in the local currency.
+	Price Price `json:"price"`
+	// ConvertedPrice of the fare, in the currency requested with TollOptions.Currency.
+	ConvertedPrice *Price `json:"convertedPrice,omitempty"`
+	// Reason for the fare, e.g. toll or ferry.
+	Reason string `json:"reason,omitempty"`
+	// PaymentMethods accepted for the fare, e.g. cash, bankCard or transponder.
+	PaymentMethods []string `json:"paymentMethods,omitempty"`
+}
+
+// Price is a monetary amount, or a range of amounts.
+type Price struct {
+	// Type of the price, either value or range.
+	Type string `json:"type,omitempty"`
+	// Currency of the price, as an ISO 4217 currency code.
+	Currency string `json:"currency"`
+	// Value of the price. Set when Type is value.
+	Value float64 `json:"value,omitempty"`
+	// Minimum of the price. Set when Type is range.
+	Minimum float64 `json:"minimum,omitempty"`
+	// Maximum of the price. Set when Type is range.
+	Maximum float64 `json:"maximum,omitempty"`
+	// Estimated is true if the price is an estimate.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// Amount returns the value of the price, or the maximum when the price is a range.
+func (p *Price) Amount() float64 {
+	if p.Type == "range" {
+		return p.Maximum
+	}
+	return p.Value
+}
+
+// TollSystem is an operator collecting tolls.
+type TollSystem struct {
+	// Name of the toll system.
+	Name string `json:"name"`
+}
+
+// TollCollectionLocation is a place where a toll is collected, e.g. a toll booth.
+type TollCollectionLocation struct {
+	// Name of the location.
+	Name string `json:"name,omitempty"`
+	// Location in lat and long.
+	Location GeoWaypoint `json:"location"`
+}
+
+// TollCostByCurrency returns the total toll cost of the route, per currency.
+// The first fare of each toll is used, and its converted price is preferred when present.
+func (r *Route) TollCostByCurrency() map[string]float64 {
+	costs := make(map[string]float64)
+	for _, section := range r.Sections {
+		for _, toll := range section.Tolls {
+			if len(toll.Fares) == 0 {
+				continue
+			}
+			price := toll.Fares[0].Price
+			if toll.Fares[0].ConvertedPrice != nil {
+				price = *toll.Fares[0].ConvertedPrice
+			}
+			costs[price.Currency] += price.Amount()
+		}
+	}
+	return costs
+}
+
+// ActionLocations returns the location of each of the actions, looked up by their offset in the polyline of
+// the section. Requires that the polyline was requested with PolylineReturnAttribute.
+func (s *Section) ActionLocations(actions []Action) ([]GeoWaypoint, error) {
+	waypoints, err := s.Polyline.Decode()
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]GeoWaypoint, 0, len(actions))
+	for i, action := range actions {
+		if action.Offset < 0 || action.Offset >= len(waypoints) {
+			return nil, fmt.Errorf("action %d: offset %d outside polyline of %d points", i, action.Offset, len(waypoints))
+		}
+		locations = append(locations, waypoints[action.Offset])
+	}
+	return locations, nil
+}
+
+// Action is a maneuver, e.g. a turn, to follow along a section.
+type Action struct {
+	// Action type, e.g. ActionTypeTurn.
+	Action ActionType `json:"action"`
+	// Duration of the action, in seconds, i.e. until the next action.
+	Duration int32 `json:"duration"`
+	// Length of the action, in meters, i.e. until the next action.
+	Length int32 `json:"length"`
+	// Instruction is a human-readable description of the action, in the language given by the request.
+	// Returned with InstructionsReturnAttribute.
+	Instruction string `json:"instruction,omitempty"`
+	// Offset of the action in the polyline of the section.
+	Offset int `json:"offset"`
+	// Direction of the action, e.g. left for a left turn.
+	Direction ActionDirection `json:"direction,omitempty"`
+	// Severity of the turn.
+	Severity ActionSeverity `json:"severity,omitempty"`
+	// Exit number of a roundabout exit.
+	Exit int `json:"exit,omitempty"`
+	// TurnAngle of the action, in degrees. Returned with TurnByTurnActionsReturnAttribute.
+	TurnAngle float64 `json:"turnAngle,omitempty"`
+	// CurrentRoad is the road before the action. Returned with TurnByTurnActionsReturnAttribute.
+	CurrentRoad *RoadInfo `json:"currentRoad,omitempty"`
+	// NextRoad is the road after the action. Returned with TurnByTurnActionsReturnAttribute.
+	NextRoad *RoadInfo `json:"nextRoad,omitempty"`
+}
+
+type ActionType string
+
+const (
+	ActionTypeDepart          ActionType = "depart"
+	ActionTypeArrive          ActionType = "arrive"
+	ActionTypeContinue        ActionType = "continue"
+	ActionTypeTurn            ActionType = "turn"
+	ActionTypeKeep            ActionType = "keep"
+	ActionTypeUTurn           ActionType = "uTurn"
+	ActionTypeRamp            ActionType = "ramp"
+	ActionTypeExit            ActionType = "exit"
+	ActionTypeContinueHighway ActionType = "continueHighway"
+	ActionTypeEnterHighway    ActionType = "enterHighway"
+	ActionTypeRoundaboutEnter ActionType = "roundaboutEnter"
+	ActionTypeRoundaboutPass  ActionType = "roundaboutPass"
+	ActionTypeRoundaboutExit  ActionType = "roundaboutExit"
+	ActionTypeBoardFerry      ActionType = "boardFerry"
+	ActionTypeDeboardFerry    ActionType = "deboardFerry"
+)
+
+type ActionDirection string
+
+const (
+	ActionDirectionLeft   ActionDirection = "left"
+	ActionDirectionRight  ActionDirection = "right"
+	ActionDirectionMiddle ActionDirection = "middle"
+)
+
+type ActionSeverity string
+
+const (
+	ActionSeverityLight ActionSeverity = "light"
+	ActionSeverityQuite ActionSeverity = "quite"
+	ActionSeverityHeavy ActionSeverity = "heavy"
+)
+
+// RoadInfo describes a road before or after an action.
+type RoadInfo struct {
+	// Type of the road, e.g. highway, rural or urban.
+	Type string `json:"type,omitempty"`
+	// Name of the road, in one or more languages.
+	Name []Name `json:"name,omitempty"`
+	// Number of the road, e.g. E4, in one or more languages.
+	Number []Name `json:"number,omitempty"`
+	// Toward is the destination signposted on the road, in one or more languages.
+	Toward []Name `json:"toward,omitempty"`
 }
 
 type Span struct {
@@ -197,7 +480,11 @@ type Name struct {
 }
 
 type VehicleDeparture struct {
-	Place Place `json:"place"`
+	// Time of departure or arrival, in RFC 3339 format.
+	Time  string `json:"time,omitempty"`
+	Place Place  `json:"place"`
+	// Charge is the state of charge of an electric vehicle, in kWh. Returned for EV routes.
+	Charge *float64 `json:"charge,omitempty"`
 }
 
 // Place with lat and long info on where the place is.
@@ -208,6 +495,42 @@ type Place struct {
 	Location GeoWaypoint `json:"location"`
 	// OriginalLocation in lat and long
 	OriginalLocation GeoWaypoint `json:"originalLocation"`
+	// Waypoint is the index of the requested waypoint this place corresponds to, if any.
+	// The origin has index 0, followed by the via waypoints and the destination.
+	Waypoint *int `json:"waypoint,omitempty"`
+	// ID of the place, e.g. of a charging station.
+	ID string `json:"id,omitempty"`
+	// Name of the place, e.g. of a charging station.
+	Name string `json:"name,omitempty"`
+	// Brand of a charging station.
+	Brand *ChargingStationBrand `json:"brand,omitempty"`
+	// Attributes of a charging station.
+	Attributes *ChargingStationAttributes `json:"attributes,omitempty"`
+}
+
+// PlaceTypeChargingStation is the type of places that are charging stations.
+const PlaceTypeChargingStation = "chargingStation"
+
+// ChargingStationBrand is the operator of a charging station.
+type ChargingStationBrand struct {
+	// HRN is the HERE Resource Name of the brand.
+	HRN string `json:"hrn,omitempty"`
+	// Name of the brand.
+	Name string `json:"name,omitempty"`
+}
+
+// ChargingStationAttributes describes the charging point used at a charging station.
+type ChargingStationAttributes struct {
+	// Power of the charging point, in kW.
+	Power float64 `json:"power,omitempty"`
+	// Current of the charging point, in A.
+	Current float64 `json:"current,omitempty"`
+	// Voltage of the charging point, in V.
+	Voltage float64 `json:"voltage,omitempty"`
+	// SupplyType of the charging point, e.g. acSingle, acThree or dc.
+	SupplyType string `json:"supplyType,omitempty"`
+	// ConnectorType of the charging point.
+	ConnectorType ConnectorType `json:"connectorType,omitempty"`
 }
 
 // Summary contains the duration and length info.
@@ -218,9 +541,12 @@ type Summary struct {
 	Length int32 `json:"length"`
 	// BaseDuration is the duration without dynamic traffic information
 	BaseDuration int32 `json:"baseDuration"`
+	// Consumption is the energy consumed by an electric vehicle, in kWh. Returned for EV routes.
+	Consumption float64 `json:"consumption,omitempty"`
 }
 
 // Polyline of a route section, encoded as a  Flexible Polyline.
+// Use Decode to get the waypoints of the polyline, and EncodePolyline to create one.
 // See https://github.com/heremaps/flexible-polyline
 type Polyline string
 
@@ -7,6 +7,7 @@ import (
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"gotest.tools/v3/assert"
@@ -26,6 +27,7 @@ func TestUnmarshalRoute(t *testing.T) {
 							ID:   "85357f8f-00ad-447e-a510-d8c02e0b264f",
 							Type: "vehicle",
 							Arrival: VehicleDeparture{
+								Time: "2019-12-09T16:05:05+01:00",
 								Place: Place{
 									Type: "place",
 									Location: GeoWaypoint{
@@ -36,6 +38,7 @@ func TestUnmarshalRoute(t *testing.T) {
 								},
 							},
 							Departure: VehicleDeparture{
+								Time: "2019-12-09T16:03:02+01:00",
 								Place: Place{
 									Type: "place",
 									Location: GeoWaypoint{
@@ -69,6 +72,7 @@ func TestUnmarshalRoute(t *testing.T) {
 							ID:   "3b64cfbf-4a78-487b-ab37-0676b06d2456",
 							Type: "vehicle",
 							Arrival: VehicleDeparture{
+								Time: "2019-12-09T11:15:43+01:00",
 								Place: Place{
 									Type: "place",
 									Location: GeoWaypoint{
@@ -79,6 +83,7 @@ func TestUnmarshalRoute(t *testing.T) {
 								},
 							},
 							Departure: VehicleDeparture{
+								Time: "2019-12-09T11:13:51+01:00",
 								Place: Place{
 									Type: "place",
 									Location: GeoWaypoint{
@@ -112,6 +117,7 @@ func TestUnmarshalRoute(t *testing.T) {
 								ID:   "7cb3dcba-6d43-41e0-95eb-3a3f3af77340",
 								Type: "vehicle",
 								Arrival: VehicleDeparture{
+									Time: "2021-11-01T10:27:04+01:00",
 									Place: Place{
 										Type: "place",
 										Location: GeoWaypoint{
@@ -125,6 +131,7 @@ func TestUnmarshalRoute(t *testing.T) {
 									},
 								},
 								Departure: VehicleDeparture{
+									Time: "2021-11-01T10:00:00+01:00",
 									Place: Place{
 										Type: "place",
 										Location: GeoWaypoint{
@@ -169,6 +176,88 @@ func TestUnmarshalRoute(t *testing.T) {
 										},
 									},
 								},
+								Actions: []Action{
+									{
+										Action:      ActionTypeDepart,
+										Duration:    282,
+										Length:      3111,
+										Instruction: "Head toward ulica Eugeniusza Kwiatkowskiego on ulica Opolska (94). Go for 3.1 km.",
+									},
+									{
+										Action:   ActionTypeRoundaboutExit,
+										Duration: 931,
+										Length:   8299,
+										Instruction: "Take the 2nd exit from roundabout onto ulica Wrocławska (94) " +
+											"toward Wrocław Księże Małe. Go for 8.3 km.",
+										Offset:    110,
+										Direction: ActionDirectionRight,
+										Exit:      2,
+									},
+									{
+										Action:      ActionTypeKeep,
+										Duration:    197,
+										Length:      1178,
+										Instruction: "Keep right onto ulica gen. Romualda Traugutta. Go for 1.2 km.",
+										Offset:      439,
+										Direction:   ActionDirectionRight,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    62,
+										Length:      103,
+										Instruction: "Turn left onto plac gen. Walerego Wróblewskiego. Go for 103 m.",
+										Offset:      501,
+										Direction:   ActionDirectionLeft,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    16,
+										Length:      44,
+										Instruction: "Turn left onto ulica Kujawska (98). Go for 44 m.",
+										Offset:      504,
+										Direction:   ActionDirectionLeft,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    78,
+										Length:      432,
+										Instruction: "Turn right onto ulica gen. Romualda Traugutta. Go for 432 m.",
+										Offset:      507,
+										Direction:   ActionDirectionRight,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeContinue,
+										Duration:    9,
+										Length:      55,
+										Instruction: "Continue on ulica Oławska. Go for 55 m.",
+										Offset:      519,
+									},
+									{
+										Action:      ActionTypeKeep,
+										Duration:    29,
+										Length:      134,
+										Instruction: "Keep right toward plac Dominikański. Go for 134 m.",
+										Offset:      521,
+										Direction:   ActionDirectionRight,
+									},
+									{
+										Action:      ActionTypeTurn,
+										Duration:    20,
+										Length:      95,
+										Instruction: "Turn right onto plac Dominikański. Go for 95 m.",
+										Offset:      527,
+										Direction:   ActionDirectionRight,
+										Severity:    ActionSeverityQuite,
+									},
+									{
+										Action:      ActionTypeArrive,
+										Instruction: "Arrive at plac Dominikański.",
+										Offset:      536,
+									},
+								},
 								Polyline: "BG6m_phDgnu3gBif3zBgKvR0K3S4NjXwHvM0FrJoGzK8G7LsJ_O0KvR8Q3c0FrJ0jBj6Bs" +
 									"YnpBwR3c8a_sBsJzPoVzjBgUvgBgFjIsE7GoGzKwHjNgKjS0F_J8G7LsJnQ8Q3coG_JoQ7a4N7Vw" +
 									"MzUwMnVkN7Vs7B_jDoQ7akI3NkIrO0KjSwHvMsEvHgFjIwHjNkN7VoG_JsEvHoGzK8GzKoG_J0F3" +
@@ -287,6 +376,140 @@ func TestUnmarshalRoute(t *testing.T) {
 	})
 }
 
+func TestUnmarshalRoute_Via(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-via.json")
+	assert.Equal(t, len(resp.Routes), 1)
+	sections := resp.Routes[0].Sections
+	assert.Equal(t, len(sections), 2)
+	for i, section := range sections {
+		assert.Equal(t, *section.Departure.Place.Waypoint, i)
+		assert.Equal(t, *section.Arrival.Place.Waypoint, i+1)
+	}
+}
+
+func TestUnmarshalRoute_Alternatives(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-alternatives.json")
+	assert.Equal(t, len(resp.Routes), 2)
+	// The main route comes first, followed by the alternatives.
+	assert.Equal(t, resp.Routes[0].ID, "5b0e4f4e-8b1a-4a54-9b0e-6c2d4a1f1a01")
+	assert.Equal(t, resp.Routes[1].ID, "5b0e4f4e-8b1a-4a54-9b0e-6c2d4a1f1a02")
+	for _, route := range resp.Routes {
+		assert.Equal(t, route.Sections[0].Arrival.Time, "2024-01-02T08:00:00+01:00")
+	}
+}
+
+func TestUnmarshalRoute_Actions(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-actions.json")
+	section := resp.Routes[0].Sections[0]
+	assert.DeepEqual(t, section.Actions, []Action{
+		{
+			Action:      ActionTypeDepart,
+			Duration:    24,
+			Length:      98,
+			Instruction: "Head west on Invalidenstraße. Go for 98 m.",
+		},
+		{
+			Action:      ActionTypeTurn,
+			Duration:    60,
+			Length:      285,
+			Instruction: "Turn right onto Chausseestraße. Go for 285 m.",
+			Offset:      8,
+			Direction:   ActionDirectionRight,
+			Severity:    ActionSeverityQuite,
+		},
+		{
+			Action:      ActionTypeArrive,
+			Instruction: "Arrive at Chausseestraße.",
+			Offset:      32,
+		},
+	})
+	assert.DeepEqual(t, section.TurnByTurnActions, []Action{
+		{
+			Action:    ActionTypeTurn,
+			Duration:  60,
+			Length:    285,
+			Offset:    8,
+			Direction: ActionDirectionRight,
+			Severity:  ActionSeverityQuite,
+			TurnAngle: 89.5,
+			CurrentRoad: &RoadInfo{
+				Type: "urban",
+				Name: []Name{{Language: "de", Value: "Invalidenstraße"}},
+			},
+			NextRoad: &RoadInfo{
+				Type:   "urban",
+				Name:   []Name{{Language: "de", Value: "Chausseestraße"}},
+				Number: []Name{{Language: "de", Value: "B96"}},
+			},
+		},
+	})
+	locations, err := section.ActionLocations(section.Actions)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, locations, []GeoWaypoint{
+		{Lat: 52.530984, Long: 13.384567},
+		{Lat: 52.53058, Long: 13.38307},
+		{Lat: 52.532326, Long: 13.378874},
+	})
+	_, err = section.ActionLocations([]Action{{Offset: 33}})
+	assert.ErrorContains(t, err, "offset 33 outside polyline of 33 points")
+}
+
+func TestUnmarshalRoute_Tolls(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-tolls.json")
+	route := resp.Routes[0]
+	section := route.Sections[0]
+	assert.DeepEqual(t, section.TollSystems, []TollSystem{{Name: "ØRESUNDSBRON"}, {Name: "BRO OG TUNNEL"}})
+	assert.Equal(t, len(section.Tolls), 2)
+	assert.DeepEqual(t, section.Tolls[0].Fares[0], TollFare{
+		ID:             "f1",
+		Name:           "Øresundsbron",
+		Price:          Price{Type: "value", Currency: "SEK", Value: 590},
+		ConvertedPrice: &Price{Type: "value", Currency: "EUR", Value: 52.5},
+		Reason:         "toll",
+		PaymentMethods: []string{"cash", "bankCard"},
+	})
+	assert.DeepEqual(t, section.Tolls[0].TollCollectionLocations, []TollCollectionLocation{
+		{Name: "Lernacken", Location: GeoWaypoint{Lat: 55.5638, Long: 12.9187}},
+	})
+	assert.Equal(t, section.Tolls[1].TollSystemRef, 1)
+	assert.DeepEqual(t, route.TollCostByCurrency(), map[string]float64{"EUR": 52.5, "DKK": 20})
+}
+
+func TestUnmarshalRoute_Charging(t *testing.T) {
+	t.Parallel()
+	resp := unmarshalRouteResponseFromFile(t, "route-with-charging.json")
+	route := resp.Routes[0]
+	assert.Equal(t, route.Sections[0].Summary.Consumption, 139.5)
+	assert.Equal(t, *route.Sections[0].Departure.Charge, 180.0)
+	stops := route.ChargingStops()
+	assert.Equal(t, len(stops), 1)
+	arrivalCharge, departureCharge := 40.5, 270.0
+	assert.DeepEqual(t, stops[0], ChargingStop{
+		Place: Place{
+			Type:     PlaceTypeChargingStation,
+			Location: GeoWaypoint{Lat: 58.4108, Long: 15.6214},
+			ID:       "station-1",
+			Name:     "Linköping Truck Charging",
+			Brand:    &ChargingStationBrand{HRN: "hrn:here:search.ev-brand:1", Name: "Milence"},
+			Attributes: &ChargingStationAttributes{
+				Power:         350,
+				Current:       500,
+				Voltage:       800,
+				SupplyType:    "dc",
+				ConnectorType: ConnectorTypeIEC62196Type2Combo,
+			},
+		},
+		ArrivalCharge:    &arrivalCharge,
+		DepartureCharge:  &departureCharge,
+		Duration:         32 * time.Minute,
+		ChargingDuration: 30 * time.Minute,
+	})
+}
+
 func unmarshalRouteResponseFromFile(t *testing.T, filename string) RoutesResponse {
 	bs, err := os.ReadFile(path.Join("testdata", filename))
 	assert.NilError(t, err)
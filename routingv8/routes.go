@@ -9,9 +9,14 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"go.einride.tech/here/telemetry"
 )
 
 // Routes returns all possible routes between origin and destination.
+// If HERE responds without routes and with a critical notice, the response is returned together with a
+// *NoRouteError, classified as ErrNoRoute. Such responses used to be returned with a nil error, so callers that
+// inspect the notices of the response must check it before returning on the error.
 // See https://developer.here.com/documentation/routing-api/dev_guide/topics/send-request.html#send-a-request
 // for details about other parameters.
 func (s *RoutingService) Routes(
@@ -22,6 +27,13 @@ func (s *RoutingService) Routes(
 	if tm == invalid || tm == unspecified {
 		return nil, fmt.Errorf("invalid transportmode")
 	}
+	ctx, op := s.Client.startOperation(
+		ctx,
+		"Routes",
+		telemetry.String(telemetry.AttributeTransportMode, tm),
+		telemetry.Int(telemetry.AttributeWaypoints, len(req.Via)+2),
+	)
+	defer func() { op.End(err) }()
 
 	u, err := s.URL.Parse("routes")
 	if err != nil {
@@ -36,13 +48,26 @@ func (s *RoutingService) Routes(
 	if len(returns) == 0 {
 		returns = append(returns, string(SummaryReturnAttribute))
 	}
+	if err := validateReturn(req.Return); err != nil {
+		return nil, err
+	}
 	values.Add("return", strings.Join(returns, ","))
-	if req.DepartureTime != "" {
-		values.Add("departureTime", req.DepartureTime)
+	if len(req.Lang) > 0 {
+		values.Add("lang", strings.Join(req.Lang, ","))
+	}
+	if err := addTimeParameters(values, req.DepartureTime, req.ArrivalTime); err != nil {
+		return nil, err
+	}
+	if err := addRoutingModeParameters(values, req.RoutingMode, req.Alternatives); err != nil {
+		return nil, err
 	}
 	values.Add("transportMode", tm)
 	values.Add("origin", fmt.Sprintf("%v,%v", req.Origin.Lat, req.Origin.Long))
 	values.Add("destination", fmt.Sprintf("%v,%v", req.Destination.Lat, req.Destination.Long))
+	via, err := viaQuery(req.Via)
+	if err != nil {
+		return nil, err
+	}
 	if len(req.Spans) > 0 {
 		if !returnContains(req.Return, PolylineReturnAttribute) {
 			return nil, errors.New("spans parameter also requires that the polyline option is set in the return parameter")
@@ -66,19 +91,200 @@ func (s *RoutingService) Routes(
 		}
 		values.Add("avoid[features]", strings.Join(areas, ","))
 	}
+	if err := addAvoidParameters(values, req); err != nil {
+		return nil, err
+	}
 	if req.Vehicle != nil {
 		addVehicleParameters(values, req.Vehicle)
 	}
+	if req.Tolls != nil {
+		if !returnContains(req.Return, TollsReturnAttribute) {
+			return nil, errors.New("tolls parameter also requires that the tolls option is set in the return parameter")
+		}
+		if err := addTollParameters(values, req.Tolls); err != nil {
+			return nil, err
+		}
+	}
+	if req.EV != nil {
+		if err := addEVParameters(values, req.EV); err != nil {
+			return nil, err
+		}
+	}
 
-	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, values.Encode(), nil)
+	query := values.Encode()
+	if via != "" {
+		query += "&" + via
+	}
+	r, err := s.Client.NewRequest(ctx, u, http.MethodGet, query, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp RoutesResponse
 	if err := s.Client.Do(r, &resp); err != nil {
 		return nil, err
 	}
-	return &resp, nil
+	// The response is returned with the error, so that its notices remain available to callers inspecting them.
+	return &resp, noRouteError(&resp)
+}
+
+func addTimeParameters(values url.Values, departureTime, arrivalTime string) error {
+	if departureTime != "" && arrivalTime != "" {
+		return errors.New("departure time and arrival time can not both be set")
+	}
+	if departureTime != "" {
+		values.Add("departureTime", departureTime)
+	}
+	if arrivalTime != "" {
+		values.Add("arrivalTime", arrivalTime)
+	}
+	return nil
+}
+
+// maxAlternatives is the maximum number of alternative routes supported by the API.
+const maxAlternatives = 6
+
+func addRoutingModeParameters(values url.Values, routingMode RoutingMode, alternatives int) error {
+	rm := routingMode.String()
+	if rm == invalid {
+		return errors.New("invalid routing mode")
+	}
+	if rm != unspecified {
+		values.Add("routingMode", rm)
+	}
+	if alternatives < 0 || alternatives > maxAlternatives {
+		return fmt.Errorf("alternatives %d out of range [0-%d]", alternatives, maxAlternatives)
+	}
+	if alternatives > 0 {
+		values.Add("alternatives", strconv.Itoa(alternatives))
+	}
+	return nil
+}
+
+// viaQuery returns the raw query of the via waypoints. It is not built with url.Values, since the delimiters of
+// place options (';') and waypoint options ('!') must be sent unescaped, and only the option values escaped.
+func viaQuery(vias []Via) (string, error) {
+	query := make([]string, 0, len(vias))
+	for i, via := range vias {
+		if via.PassThrough && via.StopDuration > 0 {
+			return "", fmt.Errorf("via %d: stop duration is not allowed for pass-through waypoints", i)
+		}
+		if via.Course != nil && (*via.Course < 0 || *via.Course >= 360) {
+			return "", fmt.Errorf("via %d: course %d out of range [0-360)", i, *via.Course)
+		}
+		var b strings.Builder
+		b.WriteString("via=")
+		b.WriteString(fmt.Sprintf("%v,%v", via.Location.Lat, via.Location.Long))
+		if via.Radius > 0 {
+			b.WriteString(";radius=")
+			b.WriteString(strconv.Itoa(via.Radius))
+		}
+		if via.Course != nil {
+			b.WriteString(";course=")
+			b.WriteString(strconv.Itoa(*via.Course))
+		}
+		if via.NameHint != "" {
+			b.WriteString(";nameHint=")
+			b.WriteString(url.QueryEscape(via.NameHint))
+		}
+		if via.StopDuration > 0 {
+			b.WriteString("!stopDuration=")
+			b.WriteString(strconv.Itoa(int(via.StopDuration.Seconds())))
+		}
+		if via.PassThrough {
+			b.WriteString("!passThrough=true")
+		}
+		query = append(query, b.String())
+	}
+	return strings.Join(query, "&"), nil
+}
+
+func addAvoidParameters(values url.Values, req *RoutesRequest) error {
+	if len(req.AvoidGeoAreas) > 0 {
+		areas := make([]string, 0, len(req.AvoidGeoAreas))
+		for i, area := range req.AvoidGeoAreas {
+			a, err := formatAvoidArea(area, true)
+			if err != nil {
+				return fmt.Errorf("avoid area %d: %w", i, err)
+			}
+			areas = append(areas, a)
+		}
+		values.Add("avoid[areas]", strings.Join(areas, "|"))
+	}
+	if len(req.AvoidSegments) > 0 {
+		values.Add("avoid[segments]", strings.Join(req.AvoidSegments, ","))
+	}
+	if len(req.AvoidZoneCategories) > 0 {
+		categories := make([]string, 0, len(req.AvoidZoneCategories))
+		for _, category := range req.AvoidZoneCategories {
+			categories = append(categories, string(category))
+		}
+		values.Add("avoid[zoneCategories]", strings.Join(categories, ","))
+	}
+	if len(req.ExcludeCountries) > 0 {
+		for _, country := range req.ExcludeCountries {
+			if !isCountryCode(country) {
+				return fmt.Errorf("invalid country code '%s'", country)
+			}
+		}
+		values.Add("exclude[countries]", strings.Join(req.ExcludeCountries, ","))
+	}
+	return nil
+}
+
+func formatAvoidArea(area AvoidArea, allowExceptions bool) (string, error) {
+	var shapes int
+	var b strings.Builder
+	if area.BoundingBox != nil {
+		shapes++
+		bbox := area.BoundingBox
+		if bbox.South > bbox.North {
+			return "", fmt.Errorf("bounding box south %v is north of north %v", bbox.South, bbox.North)
+		}
+		b.WriteString("bbox:")
+		b.WriteString(strings.Join([]string{
+			formatFloat(bbox.West),
+			formatFloat(bbox.South),
+			formatFloat(bbox.East),
+			formatFloat(bbox.North),
+		}, ","))
+	}
+	if area.Polygon != nil {
+		shapes++
+		if len(area.Polygon) < 3 {
+			return "", fmt.Errorf("polygon must contain at least 3 vertices")
+		}
+		vertices := make([]string, 0, len(area.Polygon))
+		for _, vertex := range area.Polygon {
+			vertices = append(vertices, formatFloat(vertex.Lat)+","+formatFloat(vertex.Long))
+		}
+		b.WriteString("polygon:")
+		b.WriteString(strings.Join(vertices, ";"))
+	}
+	if area.Corridor != nil {
+		shapes++
+		if area.Corridor.Polyline == "" || area.Corridor.Radius <= 0 {
+			return "", fmt.Errorf("corridor must have a polyline and a positive radius")
+		}
+		b.WriteString("corridor:")
+		b.WriteString(string(area.Corridor.Polyline))
+		b.WriteString(";r=")
+		b.WriteString(strconv.Itoa(area.Corridor.Radius))
+	}
+	if shapes != 1 {
+		return "", fmt.Errorf("exactly one of bounding box, polygon and corridor must be set")
+	}
+	if len(area.Exceptions) > 0 && !allowExceptions {
+		return "", fmt.Errorf("exceptions cannot have exceptions")
+	}
+	for i, exception := range area.Exceptions {
+		e, err := formatAvoidArea(exception, false)
+		if err != nil {
+			return "", fmt.Errorf("exception %d: %w", i, err)
+		}
+		b.WriteString("!exception=")
+		b.WriteString(e)
+	}
+	return b.String(), nil
 }
 
 func addVehicleParameters(values url.Values, vehicle *Vehicle) {
@@ -103,9 +309,135 @@ func addVehicleParameters(values url.Values, vehicle *Vehicle) {
 	if vehicle.Type != "" {
 		values.Add("vehicle[type]", vehicle.Type.String())
 	}
+	if vehicle.Category != "" {
+		values.Add("vehicle[category]", string(vehicle.Category))
+	}
+}
+
+func addTollParameters(values url.Values, tolls *TollOptions) error {
+	if tolls.Currency != "" {
+		if !isCurrencyCode(tolls.Currency) {
+			return fmt.Errorf("invalid currency code '%s'", tolls.Currency)
+		}
+		values.Add("currency", tolls.Currency)
+	}
+	if tolls.Transponders != "" {
+		values.Add("tolls[transponders]", string(tolls.Transponders))
+	}
+	if tolls.Vignettes {
+		values.Add("tolls[vignettes]", "all")
+	}
+	if tolls.EmissionType != nil {
+		emissionType := string(tolls.EmissionType.Standard)
+		if tolls.EmissionType.CO2Class != 0 {
+			emissionType += ";co2class=" + strconv.Itoa(tolls.EmissionType.CO2Class)
+		}
+		values.Add("tolls[emissionType]", emissionType)
+	}
+	if len(tolls.Summaries) > 0 {
+		summaries := make([]string, 0, len(tolls.Summaries))
+		for _, summary := range tolls.Summaries {
+			summaries = append(summaries, string(summary))
+		}
+		values.Add("tolls[summaries]", strings.Join(summaries, ","))
+	}
+	return nil
+}
+
+func addEVParameters(values url.Values, ev *EV) error {
+	if len(ev.FreeFlowSpeedTable) == 0 {
+		return errors.New("ev parameter requires a free flow speed table")
+	}
+	if ev.MakeReachable {
+		if ev.InitialCharge == 0 || ev.MaxCharge == 0 || len(ev.ChargingCurve) == 0 || len(ev.ConnectorTypes) == 0 {
+			return errors.New(
+				"ev make reachable requires initial charge, max charge, charging curve and connector types",
+			)
+		}
+	}
+	if ev.MaxCharge > 0 && ev.InitialCharge > ev.MaxCharge {
+		return fmt.Errorf("ev initial charge %v exceeds max charge %v", ev.InitialCharge, ev.MaxCharge)
+	}
+	values.Add("ev[freeFlowSpeedTable]", formatSpeedConsumptions(ev.FreeFlowSpeedTable))
+	if len(ev.TrafficSpeedTable) > 0 {
+		values.Add("ev[trafficSpeedTable]", formatSpeedConsumptions(ev.TrafficSpeedTable))
+	}
+	if len(ev.ChargingCurve) > 0 {
+		points := make([]string, 0, 2*len(ev.ChargingCurve))
+		for _, point := range ev.ChargingCurve {
+			points = append(points, formatFloat(point.Charge), formatFloat(point.Power))
+		}
+		values.Add("ev[chargingCurve]", strings.Join(points, ","))
+	}
+	for _, parameter := range []struct {
+		key   string
+		value float64
+	}{
+		{key: "ev[auxiliaryConsumption]", value: ev.AuxiliaryConsumption},
+		{key: "ev[ascent]", value: ev.Ascent},
+		{key: "ev[descent]", value: ev.Descent},
+		{key: "ev[initialCharge]", value: ev.InitialCharge},
+		{key: "ev[maxCharge]", value: ev.MaxCharge},
+		{key: "ev[maxChargingVoltage]", value: ev.MaxChargingVoltage},
+		{key: "ev[maxChargeAfterChargingStation]", value: ev.MaxChargeAfterChargingStation},
+		{key: "ev[minChargeAtChargingStation]", value: ev.MinChargeAtChargingStation},
+		{key: "ev[minChargeAtDestination]", value: ev.MinChargeAtDestination},
+	} {
+		if parameter.value != 0 {
+			values.Add(parameter.key, formatFloat(parameter.value))
+		}
+	}
+	if len(ev.ConnectorTypes) > 0 {
+		connectorTypes := make([]string, 0, len(ev.ConnectorTypes))
+		for _, connectorType := range ev.ConnectorTypes {
+			connectorTypes = append(connectorTypes, string(connectorType))
+		}
+		values.Add("ev[connectorTypes]", strings.Join(connectorTypes, ","))
+	}
+	if ev.MakeReachable {
+		values.Add("ev[makeReachable]", "true")
+	}
+	return nil
+}
+
+func formatSpeedConsumptions(table []SpeedConsumption) string {
+	points := make([]string, 0, 2*len(table))
+	for _, point := range table {
+		points = append(points, formatFloat(point.Speed), formatFloat(point.Consumption))
+	}
+	return strings.Join(points, ",")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// isCurrencyCode reports whether s is formatted as an ISO 4217 currency code.
+func isCurrencyCode(s string) bool {
+	return isUpperAlpha3(s)
+}
+
+// isCountryCode reports whether s is formatted as an ISO 3166-1 alpha-3 country code.
+func isCountryCode(s string) bool {
+	return isUpperAlpha3(s)
+}
+
+func isUpperAlpha3(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
 }
 
 // RouteImport returns a route from a sequence of trace points.
+// If HERE responds without routes and with a critical notice, the response is returned together with a
+// *NoRouteError, classified as ErrNoRoute. Such responses used to be returned with a nil error, so callers that
+// inspect the notices of the response must check it before returning on the error.
 // See https://www.here.com/docs/bundle/routing-api-developer-guide-v8/page/concepts/route-import.html
 // and https://www.here.com/docs/bundle/routing-api-v8-api-reference/page/index.html#tag/Routing/operation/importRoute
 // for details.
@@ -121,6 +453,13 @@ func (s *RoutingService) RouteImport(
 	if len(req.Trace) < 2 {
 		return nil, fmt.Errorf("trace parameter must contain at least 2 waypoints")
 	}
+	ctx, op := s.Client.startOperation(
+		ctx,
+		"RouteImport",
+		telemetry.String(telemetry.AttributeTransportMode, tm),
+		telemetry.Int(telemetry.AttributeWaypoints, len(req.Trace)),
+	)
+	defer func() { op.End(err) }()
 
 	u, err := s.URL.Parse("import")
 	if err != nil {
@@ -135,9 +474,18 @@ func (s *RoutingService) RouteImport(
 	if len(returns) == 0 {
 		returns = append(returns, string(SummaryReturnAttribute))
 	}
+	if err := validateReturn(req.Return); err != nil {
+		return nil, err
+	}
 	values.Add("return", strings.Join(returns, ","))
-	if req.DepartureTime != "" {
-		values.Add("departureTime", req.DepartureTime)
+	if len(req.Lang) > 0 {
+		values.Add("lang", strings.Join(req.Lang, ","))
+	}
+	if err := addTimeParameters(values, req.DepartureTime, req.ArrivalTime); err != nil {
+		return nil, err
+	}
+	if err := addRoutingModeParameters(values, req.RoutingMode, req.Alternatives); err != nil {
+		return nil, err
 	}
 	values.Add("transportMode", tm)
 	if len(req.Spans) > 0 {
@@ -163,13 +511,57 @@ func (s *RoutingService) RouteImport(
 
 	r, err := s.Client.NewRequest(ctx, u, http.MethodPost, values.Encode(), bytes)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create get request: %v", err)
+		return nil, fmt.Errorf("unable to create get request: %w", err)
 	}
 	var resp RoutesResponse
 	if err := s.Client.Do(r, &resp); err != nil {
 		return nil, err
 	}
-	return &resp, nil
+	// The response is returned with the error, so that its notices remain available to callers inspecting them.
+	return &resp, noRouteError(&resp)
+}
+
+// NoRouteError is returned when HERE responds without routes, with critical notices explaining why.
+// It is classified as ErrNoRoute, and returned together with the response.
+type NoRouteError struct {
+	// Notices of the response.
+	Notices []RouteResponseNotice
+}
+
+func (e *NoRouteError) Error() string {
+	msg := "no route found"
+	for _, notice := range e.Notices {
+		if notice.Severity == CriticalNoticeSeverity {
+			msg += fmt.Sprintf(": %s (%s)", notice.Title, notice.Code)
+			break
+		}
+	}
+	return msg
+}
+
+// Is reports whether target is ErrNoRoute.
+func (e *NoRouteError) Is(target error) bool {
+	return target == ErrNoRoute
+}
+
+// noRouteError returns a NoRouteError if the response has no routes and a critical notice.
+func noRouteError(resp *RoutesResponse) error {
+	if len(resp.Routes) > 0 {
+		return nil
+	}
+	for _, notice := range resp.Notices {
+		if notice.Severity == CriticalNoticeSeverity {
+			return &NoRouteError{Notices: resp.Notices}
+		}
+	}
+	return nil
+}
+
+func validateReturn(requested []ReturnAttribute) error {
+	if returnContains(requested, InstructionsReturnAttribute) && !returnContains(requested, ActionsReturnAttribute) {
+		return errors.New("instructions option in the return parameter also requires the actions option")
+	}
+	return nil
 }
 
 func returnContains(requested []ReturnAttribute, needle ReturnAttribute) bool {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"go.einride.tech/here/routingv8"
 	"gotest.tools/v3/assert"
@@ -128,6 +129,7 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 		Lat:  59.337492,
 		Long: 18.063672,
 	}
+	course := 90
 
 	for _, tt := range []struct {
 		name     string
@@ -145,6 +147,41 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
 				"&return=summary&transportMode=car",
 		},
+		{
+			name: "with arrival time, routing mode and alternatives",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				ArrivalTime:   "2024-01-02T08:00:00+01:00",
+				RoutingMode:   routingv8.RoutingModeShort,
+				Alternatives:  3,
+			},
+			expected: "alternatives=3&arrivalTime=2024-01-02T08%3A00%3A00%2B01%3A00" +
+				"&destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary&routingMode=short&transportMode=truck",
+		},
+		{
+			name: "with departure time and arrival time",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				DepartureTime: "2024-01-02T06:00:00+01:00",
+				ArrivalTime:   "2024-01-02T08:00:00+01:00",
+			},
+			errStr: "departure time and arrival time can not both be set",
+		},
+		{
+			name: "with too many alternatives",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Alternatives:  7,
+			},
+			errStr: "alternatives 7 out of range [0-6]",
+		},
 		{
 			name: "multiple avoid areas",
 			request: &routingv8.RoutesRequest{
@@ -209,6 +246,262 @@ func TestRoutingervice_Routes_QueryParams(t *testing.T) {
 			},
 			errStr: "spans parameter also requires that the polyline option is set in the return parameter",
 		},
+		{
+			name: "with actions and instructions",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.ActionsReturnAttribute,
+					routingv8.InstructionsReturnAttribute,
+				},
+				Lang: []string{"sv-SE", "en-US"},
+			},
+			expected: "destination=59.337492%2C18.063672&lang=sv-SE%2Cen-US&origin=57.707752%2C11.949767" +
+				"&return=actions%2Cinstructions&transportMode=car",
+		},
+		{
+			name: "with instructions without actions",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeCar,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.InstructionsReturnAttribute,
+				},
+			},
+			errStr: "instructions option in the return parameter also requires the actions option",
+		},
+		{
+			name: "with tolls",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Return: []routingv8.ReturnAttribute{
+					routingv8.SummaryReturnAttribute,
+					routingv8.TollsReturnAttribute,
+				},
+				Vehicle: &routingv8.Vehicle{
+					Category: routingv8.VehicleCategoryLightTruck,
+				},
+				Tolls: &routingv8.TollOptions{
+					Currency:     "EUR",
+					Transponders: routingv8.TollTranspondersAll,
+					Vignettes:    true,
+					EmissionType: &routingv8.TollEmissionType{
+						Standard: routingv8.EmissionStandardEuro6,
+						CO2Class: 2,
+					},
+					Summaries: []routingv8.TollSummary{routingv8.TollSummaryTotal, routingv8.TollSummaryCountry},
+				},
+			},
+			expected: "currency=EUR&destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary%2Ctolls&tolls%5BemissionType%5D=euro6%3Bco2class%3D2" +
+				"&tolls%5Bsummaries%5D=total%2Ccountry&tolls%5Btransponders%5D=all&tolls%5Bvignettes%5D=all" +
+				"&transportMode=truck&vehicle%5Bcategory%5D=lightTruck",
+		},
+		{
+			name: "with tolls without wanted tolls returned",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Tolls:         &routingv8.TollOptions{Currency: "EUR"},
+			},
+			errStr: "tolls parameter also requires that the tolls option is set in the return parameter",
+		},
+		{
+			name: "with tolls with invalid currency",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				Return:        []routingv8.ReturnAttribute{routingv8.TollsReturnAttribute},
+				Tolls:         &routingv8.TollOptions{Currency: "euro"},
+			},
+			errStr: "invalid currency code 'euro'",
+		},
+		{
+			name: "with ev",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable: []routingv8.SpeedConsumption{
+						{Speed: 0, Consumption: 0.9},
+						{Speed: 80, Consumption: 1.2},
+					},
+					InitialCharge: 300,
+					MaxCharge:     540,
+					ChargingCurve: []routingv8.ChargingCurvePoint{
+						{Charge: 0, Power: 350},
+						{Charge: 432, Power: 150},
+					},
+					MinChargeAtChargingStation: 54,
+					ConnectorTypes:             []routingv8.ConnectorType{routingv8.ConnectorTypeIEC62196Type2Combo},
+					MakeReachable:              true,
+				},
+			},
+			expected: "destination=59.337492%2C18.063672" +
+				"&ev%5BchargingCurve%5D=0%2C350%2C432%2C150&ev%5BconnectorTypes%5D=iec62196Type2Combo" +
+				"&ev%5BfreeFlowSpeedTable%5D=0%2C0.9%2C80%2C1.2&ev%5BinitialCharge%5D=300" +
+				"&ev%5BmakeReachable%5D=true&ev%5BmaxCharge%5D=540&ev%5BminChargeAtChargingStation%5D=54" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=truck",
+		},
+		{
+			name: "with ev make reachable without charging curve",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				EV: &routingv8.EV{
+					FreeFlowSpeedTable: []routingv8.SpeedConsumption{{Speed: 0, Consumption: 0.9}},
+					InitialCharge:      300,
+					MaxCharge:          540,
+					MakeReachable:      true,
+				},
+			},
+			errStr: "ev make reachable requires initial charge, max charge, charging curve and connector types",
+		},
+		{
+			name: "with ev without free flow speed table",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				EV:            &routingv8.EV{InitialCharge: 300},
+			},
+			errStr: "ev parameter requires a free flow speed table",
+		},
+		{
+			name: "with avoid geo areas, segments, zone categories and excluded countries",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				AvoidGeoAreas: []routingv8.AvoidArea{
+					{
+						BoundingBox: &routingv8.BoundingBox{West: 11.9, South: 57.6, East: 12.1, North: 57.8},
+						Exceptions: []routingv8.AvoidArea{
+							{BoundingBox: &routingv8.BoundingBox{West: 11.95, South: 57.7, East: 11.96, North: 57.71}},
+						},
+					},
+					{
+						Polygon: []routingv8.GeoWaypoint{
+							{Lat: 58.1, Long: 14.1},
+							{Lat: 58.2, Long: 14.2},
+							{Lat: 58.1, Long: 14.3},
+						},
+					},
+					{
+						Corridor: &routingv8.Corridor{Polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y", Radius: 50},
+					},
+				},
+				AvoidSegments: []string{"here:cm:segment:76771992#+", "here:cm:segment:76771993"},
+				AvoidZoneCategories: []routingv8.ZoneCategory{
+					routingv8.ZoneCategoryEnvironmental,
+					routingv8.ZoneCategoryCongestionPricing,
+				},
+				ExcludeCountries: []string{"NOR", "DNK"},
+			},
+			expected: "avoid%5Bareas%5D=bbox%3A11.9%2C57.6%2C12.1%2C57.8%21exception%3Dbbox%3A11.95%2C57.7%2C11.96%2C57.71" +
+				"%7Cpolygon%3A58.1%2C14.1%3B58.2%2C14.2%3B58.1%2C14.3" +
+				"%7Ccorridor%3ABFoz5xJ67i1B1B7PzIhaxL7Y%3Br%3D50" +
+				"&avoid%5Bsegments%5D=here%3Acm%3Asegment%3A76771992%23%2B%2Chere%3Acm%3Asegment%3A76771993" +
+				"&avoid%5BzoneCategories%5D=environmental%2CcongestionPricing" +
+				"&destination=59.337492%2C18.063672&exclude%5Bcountries%5D=NOR%2CDNK" +
+				"&origin=57.707752%2C11.949767&return=summary&transportMode=truck",
+		},
+		{
+			name: "with avoid geo area with two shapes",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				AvoidGeoAreas: []routingv8.AvoidArea{
+					{
+						BoundingBox: &routingv8.BoundingBox{West: 11.9, South: 57.6, East: 12.1, North: 57.8},
+						Corridor:    &routingv8.Corridor{Polyline: "BFoz5xJ67i1B1B7PzIhaxL7Y", Radius: 50},
+					},
+				},
+			},
+			errStr: "avoid area 0: exactly one of bounding box, polygon and corridor must be set",
+		},
+		{
+			name: "with avoid geo area with nested exceptions",
+			request: &routingv8.RoutesRequest{
+				Origin:        origin,
+				Destination:   destination,
+				TransportMode: routingv8.TransportModeTruck,
+				AvoidGeoAreas: []routingv8.AvoidArea{
+					{
+						BoundingBox: &routingv8.BoundingBox{West: 11.9, South: 57.6, East: 12.1, North: 57.8},
+						Exceptions: []routingv8.AvoidArea{
+							{
+								BoundingBox: &routingv8.BoundingBox{West: 11.9, South: 57.6, East: 12.1, North: 57.8},
+								Exceptions: []routingv8.AvoidArea{
+									{BoundingBox: &routingv8.BoundingBox{West: 11.9, South: 57.6, East: 12.1, North: 57.8}},
+								},
+							},
+						},
+					},
+				},
+			},
+			errStr: "avoid area 0: exception 0: exceptions cannot have exceptions",
+		},
+		{
+			name: "with invalid excluded country",
+			request: &routingv8.RoutesRequest{
+				Origin:           origin,
+				Destination:      destination,
+				TransportMode:    routingv8.TransportModeTruck,
+				ExcludeCountries: []string{"NO"},
+			},
+			errStr: "invalid country code 'NO'",
+		},
+		{
+			name: "with via",
+			request: &routingv8.RoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				Via: []routingv8.Via{
+					{
+						Location:     routingv8.GeoWaypoint{Lat: 58.41, Long: 15.62},
+						StopDuration: 10 * time.Minute,
+					},
+					{
+						Location:    routingv8.GeoWaypoint{Lat: 58.75, Long: 17.01},
+						PassThrough: true,
+						Radius:      100,
+						Course:      &course,
+						NameHint:    "E4; Södertälje!",
+					},
+				},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			expected: "destination=59.337492%2C18.063672&origin=57.707752%2C11.949767" +
+				"&return=summary&transportMode=car&via=58.41,15.62!stopDuration=600" +
+				"&via=58.75,17.01;radius=100;course=90;nameHint=E4%3B+S%C3%B6dert%C3%A4lje%21!passThrough=true",
+		},
+		{
+			name: "with pass-through via with stop duration",
+			request: &routingv8.RoutesRequest{
+				Origin:      origin,
+				Destination: destination,
+				Via: []routingv8.Via{
+					{
+						Location:     routingv8.GeoWaypoint{Lat: 58.41, Long: 15.62},
+						StopDuration: 10 * time.Minute,
+						PassThrough:  true,
+					},
+				},
+				TransportMode: routingv8.TransportModeCar,
+			},
+			errStr: "via 0: stop duration is not allowed for pass-through waypoints",
+		},
 		{
 			name: "with vehicle",
 			request: &routingv8.RoutesRequest{
@@ -280,6 +573,80 @@ func TestRoutingervice_Routes_Error(t *testing.T) {
 	assert.Check(t, responseError.HTTPBody != "")
 }
 
+func TestRoutingService_NoRoute(t *testing.T) {
+	t.Parallel()
+	noRoute := []routingv8.RouteResponseNotice{
+		{
+			Title:    "Route calculation failed: Couldn't find a route.",
+			Code:     "noRouteFound",
+			Severity: routingv8.CriticalNoticeSeverity,
+		},
+	}
+	origin := routingv8.GeoWaypoint{Lat: 57.707752, Long: 11.949767}
+	destination := routingv8.GeoWaypoint{Lat: 40.712776, Long: -74.005974}
+	for _, tt := range []struct {
+		name    string
+		notices []routingv8.RouteResponseNotice
+		call    func(*routingv8.Client) (*routingv8.RoutesResponse, error)
+		errStr  string
+	}{
+		{
+			name:    "routes",
+			notices: noRoute,
+			call: func(client *routingv8.Client) (*routingv8.RoutesResponse, error) {
+				return client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+					Origin:        origin,
+					Destination:   destination,
+					TransportMode: routingv8.TransportModeCar,
+				})
+			},
+			errStr: "no route found: Route calculation failed: Couldn't find a route. (noRouteFound)",
+		},
+		{
+			name:    "route import",
+			notices: noRoute,
+			call: func(client *routingv8.Client) (*routingv8.RoutesResponse, error) {
+				return client.Routing.RouteImport(context.Background(), &routingv8.RouteImportRequest{
+					Trace:         []routingv8.GeoWaypoint{origin, destination},
+					TransportMode: routingv8.TransportModeCar,
+				})
+			},
+			errStr: "no route found: Route calculation failed: Couldn't find a route. (noRouteFound)",
+		},
+		{
+			name:    "without critical notice",
+			notices: []routingv8.RouteResponseNotice{{Title: "Info", Code: "info", Severity: "info"}},
+			call: func(client *routingv8.Client) (*routingv8.RoutesResponse, error) {
+				return client.Routing.Routes(context.Background(), &routingv8.RoutesRequest{
+					Origin:        origin,
+					Destination:   destination,
+					TransportMode: routingv8.TransportModeCar,
+				})
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			httpClient := RoutesMock{responseBody: routingv8.RoutesResponse{Notices: tt.notices}, responseStatus: 200}
+			resp, err := tt.call(routingv8.NewClient(&httpClient))
+			// The response is returned with the error, for callers inspecting its notices.
+			assert.Assert(t, resp != nil)
+			assert.Equal(t, len(resp.Routes), 0)
+			assert.DeepEqual(t, resp.Notices, tt.notices)
+			if tt.errStr == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Error(t, err, tt.errStr)
+			assert.Assert(t, errors.Is(err, routingv8.ErrNoRoute))
+			var noRouteError *routingv8.NoRouteError
+			assert.Assert(t, errors.As(err, &noRouteError))
+			assert.DeepEqual(t, noRouteError.Notices, tt.notices)
+		})
+	}
+}
+
 func TestRoutingervice_RouteImport(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -446,6 +813,35 @@ func TestRoutingervice_RouteImport_QueryParams(t *testing.T) {
 				"&vehicle%5Bwidth%5D=250",
 			expectedBody: traceBody,
 		},
+		{
+			name: "with arrival time, routing mode and alternatives",
+			request: &routingv8.RouteImportRequest{
+				Trace: []routingv8.GeoWaypoint{
+					origin,
+					destination,
+				},
+				TransportMode: routingv8.TransportModeCar,
+				ArrivalTime:   "2024-01-02T08:00:00Z",
+				RoutingMode:   routingv8.RoutingModeFast,
+				Alternatives:  1,
+			},
+			expectedURLParams: "alternatives=1&arrivalTime=2024-01-02T08%3A00%3A00Z&return=summary" +
+				"&routingMode=fast&transportMode=car",
+			expectedBody: traceBody,
+		},
+		{
+			name: "with departure time and arrival time",
+			request: &routingv8.RouteImportRequest{
+				Trace: []routingv8.GeoWaypoint{
+					origin,
+					destination,
+				},
+				TransportMode: routingv8.TransportModeCar,
+				DepartureTime: "2024-01-02T06:00:00Z",
+				ArrivalTime:   "2024-01-02T08:00:00Z",
+			},
+			errStr: "departure time and arrival time can not both be set",
+		},
 		{
 			name: "trace with too few point",
 			request: &routingv8.RouteImportRequest{
@@ -0,0 +1,155 @@
+package routingv8_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"go.einride.tech/here/heretest"
+	"go.einride.tech/here/routingv8"
+	"go.einride.tech/here/telemetry"
+	"gotest.tools/v3/assert"
+)
+
+type recordedSpan struct {
+	name       string
+	parent     *recordedSpan
+	attributes map[string]interface{}
+	errors     int
+}
+
+type spanKey struct{}
+
+// recorder is a telemetry.Tracer and telemetry.Metrics which records spans, in the order they end, and
+// measurements.
+type recorder struct {
+	mu           sync.Mutex
+	spans        []*recordedSpan
+	measurements []telemetry.Measurement
+}
+
+func (r *recorder) Start(
+	ctx context.Context,
+	operation string,
+	attributes ...telemetry.Attribute,
+) (context.Context, telemetry.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	recorded := &recordedSpan{name: operation, parent: parent, attributes: map[string]interface{}{}}
+	span := &recorderSpan{recorder: r, span: recorded}
+	span.SetAttributes(attributes...)
+	return context.WithValue(ctx, spanKey{}, recorded), span
+}
+
+func (r *recorder) Record(_ context.Context, measurement telemetry.Measurement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.measurements = append(r.measurements, measurement)
+}
+
+type recorderSpan struct {
+	recorder *recorder
+	span     *recordedSpan
+}
+
+func (s *recorderSpan) SetAttributes(attributes ...telemetry.Attribute) {
+	for _, attribute := range attributes {
+		s.span.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *recorderSpan) RecordError(error) {
+	s.span.errors++
+}
+
+func (s *recorderSpan) End() {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, s.span)
+}
+
+func TestClient_Telemetry(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{APIKey: "key", PendingPolls: 1})
+	t.Cleanup(server.Close)
+	var recorder recorder
+	client := routingv8.NewClient(
+		routingv8.NewAPIKeyHTTPClient("key", nil),
+		routingv8.WithBaseURL(server.BaseURL()),
+		routingv8.WithTracer(&recorder),
+		routingv8.WithMetrics(&recorder),
+	)
+	ctx := context.Background()
+	origin := routingv8.GeoWaypoint{Lat: 57.70775, Long: 11.94977}
+	destination := routingv8.GeoWaypoint{Lat: 59.33749, Long: 18.06367}
+	_, err := client.Routing.Routes(ctx, &routingv8.RoutesRequest{
+		Origin:        origin,
+		Destination:   destination,
+		Via:           []routingv8.Via{{Location: routingv8.GeoWaypoint{Lat: 58.4, Long: 15.6}}},
+		TransportMode: routingv8.TransportModeTruck,
+	})
+	assert.NilError(t, err)
+	status, err := client.Matrix.CalculateMatrixAsync(ctx, &routingv8.CalculateMatrixRequest{
+		Body: &routingv8.CalculateMatrixBody{
+			Origins:          []*routingv8.GeoWaypoint{&origin, &destination},
+			RegionDefinition: routingv8.RegionDefinition{Type: routingv8.RegionTypeWorld},
+			TransportMode:    routingv8.TransportModeCar,
+		},
+	})
+	assert.NilError(t, err)
+	_, err = client.Matrix.WaitForMatrix(ctx, &routingv8.WaitForMatrixRequest{
+		MatrixID:     status.MatrixID,
+		PollInterval: time.Millisecond,
+	})
+	assert.NilError(t, err)
+	_, err = client.Matrix.MatrixStatus(ctx, &routingv8.MatrixStatusRequest{MatrixID: "unknown"})
+	assert.ErrorContains(t, err, "404")
+	names := make([]string, 0, len(recorder.spans))
+	for _, span := range recorder.spans {
+		if span.parent != nil {
+			names = append(names, span.parent.name+"/"+span.name)
+		} else {
+			names = append(names, span.name)
+		}
+	}
+	// Every service call is a span, with a child span per request. WaitForMatrix polls the status once while the
+	// matrix is pending, and then follows the redirect to the result.
+	assert.DeepEqual(t, names, []string{
+		"Routes/GET",
+		"Routes",
+		"CalculateMatrixAsync/POST",
+		"CalculateMatrixAsync",
+		"WaitForMatrix/GET",
+		"WaitForMatrix/GET",
+		"WaitForMatrix",
+		"MatrixStatus/GET",
+		"MatrixStatus",
+	})
+	routes := recorder.spans[1]
+	assert.Equal(t, routes.attributes[telemetry.AttributeAPI], "routingv8")
+	assert.Equal(t, routes.attributes[telemetry.AttributeTransportMode], "truck")
+	assert.Equal(t, routes.attributes[telemetry.AttributeWaypoints], 3)
+	assert.Equal(t, routes.errors, 0)
+	routesRequest := recorder.spans[0]
+	assert.Equal(t, routesRequest.attributes[telemetry.AttributeHTTPMethod], http.MethodGet)
+	assert.Equal(t, routesRequest.attributes[telemetry.AttributeHTTPStatusCode], http.StatusOK)
+	assert.Assert(t, routesRequest.attributes[telemetry.AttributeResponseSize].(int64) > 0)
+	assert.Equal(t, routesRequest.errors, 0)
+	matrix := recorder.spans[3]
+	assert.Equal(t, matrix.attributes[telemetry.AttributeTransportMode], "car")
+	assert.Equal(t, matrix.attributes[telemetry.AttributeOrigins], 2)
+	assert.Equal(t, matrix.attributes[telemetry.AttributeDestinations], 2)
+	matrixRequest := recorder.spans[2]
+	assert.Equal(t, matrixRequest.attributes[telemetry.AttributeHTTPStatusCode], http.StatusAccepted)
+	assert.Assert(t, matrixRequest.attributes[telemetry.AttributeRequestSize].(int64) > 0)
+	// The failed request fails its service call as well.
+	assert.Equal(t, recorder.spans[7].errors, 1)
+	assert.Equal(t, recorder.spans[8].errors, 1)
+	operations := []string{"Routes", "CalculateMatrixAsync", "WaitForMatrix", "WaitForMatrix", "MatrixStatus"}
+	assert.Equal(t, len(recorder.measurements), len(operations))
+	for i, measurement := range recorder.measurements {
+		assert.Equal(t, measurement.Operation, operations[i])
+		assert.Equal(t, measurement.Failed(), i == 4)
+	}
+}
@@ -0,0 +1,205 @@
+package routingv8
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Default tile limits, matching the limits of synchronous requests in flexible mode.
+// See https://www.here.com/docs/bundle/matrix-routing-api-developer-guide-v8/page/topics/limitations.html
+const (
+	defaultMaxOriginsPerTile      = 15
+	defaultMaxDestinationsPerTile = 100
+	defaultMaxTileConcurrency     = 4
+)
+
+// CalculateTiledMatrix returns a matrix of route summaries for origin and destination sets that are larger than
+// the limits of a single matrix request.
+// The origins and destinations are partitioned into tiles which are calculated concurrently and merged into a
+// single matrix, indexed by the original order of the origins and destinations.
+// The MatrixID of the response is empty when the matrix was calculated in more than one tile.
+func (s *MatrixService) CalculateTiledMatrix(
+	ctx context.Context,
+	req *CalculateTiledMatrixRequest,
+) (_ *CalculateMatrixResponse, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("calculate tiled matrix: %w", err)
+		}
+	}()
+	if req.Request == nil || req.Request.Body == nil {
+		return nil, fmt.Errorf("request body must be provided")
+	}
+	ctx, op := s.Client.startOperation(ctx, "CalculateTiledMatrix", matrixAttributes(req.Request.Body)...)
+	defer func() { op.End(err) }()
+	request := req.Request
+	if len(request.Body.Destinations) == 0 {
+		// Without destinations, HERE uses the origins as destinations. Tiles need them explicitly, since a tile of
+		// origins would otherwise only be routed to the origins of the same tile.
+		body := *request.Body
+		body.Destinations = body.Origins
+		request = &CalculateMatrixRequest{Async: request.Async, Body: &body}
+	}
+	body := request.Body
+	maxOrigins := req.MaxOriginsPerTile
+	if maxOrigins <= 0 {
+		maxOrigins = defaultMaxOriginsPerTile
+	}
+	maxDestinations := req.MaxDestinationsPerTile
+	if maxDestinations <= 0 {
+		maxDestinations = defaultMaxDestinationsPerTile
+	}
+	concurrency := req.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultMaxTileConcurrency
+	}
+	tiles := matrixTiles(len(body.Origins), len(body.Destinations), maxOrigins, maxDestinations)
+	if len(tiles) == 1 {
+		return s.calculateMatrixTile(ctx, request, tiles[0])
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	responses := make([]*CalculateMatrixResponse, len(tiles))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var tileErr error
+	for i, tile := range tiles {
+		i, tile := i, tile
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-semaphore }()
+			resp, err := s.calculateMatrixTile(ctx, request, tile)
+			if err != nil {
+				errOnce.Do(func() {
+					tileErr = fmt.Errorf("tile %d: %w", i, err)
+					cancel()
+				})
+				return
+			}
+			responses[i] = resp
+		}()
+	}
+	wg.Wait()
+	if tileErr != nil {
+		return nil, tileErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &CalculateMatrixResponse{
+		Matrix:           mergeMatrixTiles(len(body.Origins), len(body.Destinations), tiles, responses),
+		RegionDefinition: responses[0].RegionDefinition,
+	}, nil
+}
+
+func (s *MatrixService) calculateMatrixTile(
+	ctx context.Context,
+	req *CalculateMatrixRequest,
+	tile matrixTile,
+) (*CalculateMatrixResponse, error) {
+	body := *req.Body
+	body.Origins = body.Origins[tile.originOffset : tile.originOffset+tile.numOrigins]
+	body.Destinations = body.Destinations[tile.destinationOffset : tile.destinationOffset+tile.numDestinations]
+	tileReq := CalculateMatrixRequest{Async: req.Async, Body: &body}
+	var resp *CalculateMatrixResponse
+	if req.Async {
+		status, err := s.CalculateMatrixAsync(ctx, &tileReq)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = s.WaitForMatrix(ctx, &WaitForMatrixRequest{MatrixID: status.MatrixID})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		resp, err = s.CalculateMatrix(ctx, &tileReq)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.Matrix.NumOrigins != tile.numOrigins || resp.Matrix.NumDestinations != tile.numDestinations {
+		return nil, fmt.Errorf(
+			"unexpected matrix size %dx%d, expected %dx%d",
+			resp.Matrix.NumOrigins,
+			resp.Matrix.NumDestinations,
+			tile.numOrigins,
+			tile.numDestinations,
+		)
+	}
+	return resp, nil
+}
+
+// matrixTile is a rectangular part of a matrix.
+type matrixTile struct {
+	originOffset      int
+	numOrigins        int
+	destinationOffset int
+	numDestinations   int
+}
+
+func matrixTiles(numOrigins, numDestinations, maxOrigins, maxDestinations int) []matrixTile {
+	var tiles []matrixTile
+	for o := 0; o < numOrigins || o == 0; o += maxOrigins {
+		for d := 0; d < numDestinations || d == 0; d += maxDestinations {
+			tiles = append(tiles, matrixTile{
+				originOffset:      o,
+				numOrigins:        min(maxOrigins, numOrigins-o),
+				destinationOffset: d,
+				numDestinations:   min(maxDestinations, numDestinations-d),
+			})
+		}
+	}
+	return tiles
+}
+
+func mergeMatrixTiles(
+	numOrigins int,
+	numDestinations int,
+	tiles []matrixTile,
+	responses []*CalculateMatrixResponse,
+) MatrixResponse {
+	merged := MatrixResponse{
+		NumOrigins:      numOrigins,
+		NumDestinations: numDestinations,
+	}
+	size := numOrigins * numDestinations
+	for _, resp := range responses {
+		if resp.Matrix.TravelTimes != nil && merged.TravelTimes == nil {
+			merged.TravelTimes = make([]int32, size)
+		}
+		if resp.Matrix.Distances != nil && merged.Distances == nil {
+			merged.Distances = make([]int32, size)
+		}
+		if len(resp.Matrix.ErrorCodes) > 0 && merged.ErrorCodes == nil {
+			merged.ErrorCodes = make(ErrorCodes, size)
+		}
+	}
+	for i, tile := range tiles {
+		matrix := responses[i].Matrix
+		for o := 0; o < tile.numOrigins; o++ {
+			for d := 0; d < tile.numDestinations; d++ {
+				src := o*tile.numDestinations + d
+				dst := (tile.originOffset+o)*numDestinations + tile.destinationOffset + d
+				if merged.TravelTimes != nil && src < len(matrix.TravelTimes) {
+					merged.TravelTimes[dst] = matrix.TravelTimes[src]
+				}
+				if merged.Distances != nil && src < len(matrix.Distances) {
+					merged.Distances[dst] = matrix.Distances[src]
+				}
+				if merged.ErrorCodes != nil && src < len(matrix.ErrorCodes) {
+					merged.ErrorCodes[dst] = matrix.ErrorCodes[src]
+				}
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,206 @@
+package routingv8_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"go.einride.tech/here/routingv8"
+	"gotest.tools/v3/assert"
+)
+
+// TiledMatrixMock calculates a matrix where the travel time between an origin and a destination is
+// origin.Lat*1000 + destination.Lat, and fails the routes to destinations with a negative latitude.
+type TiledMatrixMock struct {
+	mu          sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *TiledMatrixMock) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.calls++
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+	var body struct {
+		Origins      []routingv8.GeoWaypoint `json:"origins"`
+		Destinations []routingv8.GeoWaypoint `json:"destinations"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	matrix := routingv8.MatrixResponse{
+		NumOrigins:      len(body.Origins),
+		NumDestinations: len(body.Destinations),
+	}
+	var errorCodes []int
+	for _, origin := range body.Origins {
+		for _, destination := range body.Destinations {
+			if destination.Lat < 0 {
+				matrix.TravelTimes = append(matrix.TravelTimes, 0)
+				errorCodes = append(errorCodes, routingv8.ErrorCodeDisconnected)
+				continue
+			}
+			matrix.TravelTimes = append(matrix.TravelTimes, int32(origin.Lat*1000+destination.Lat))
+			errorCodes = append(errorCodes, routingv8.ErrorCodeSuccess)
+		}
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"matrixId": "tile",
+		"matrix": map[string]interface{}{
+			"numOrigins":      matrix.NumOrigins,
+			"numDestinations": matrix.NumDestinations,
+			"travelTimes":     matrix.TravelTimes,
+			"errorCodes":      errorCodes,
+		},
+		"regionDefinition": map[string]interface{}{"type": "world"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	headers.Add("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        headers,
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+	}, nil
+}
+
+func TestMatrixService_CalculateTiledMatrix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const numOrigins, numDestinations = 7, 5
+	origins := make([]*routingv8.GeoWaypoint, 0, numOrigins)
+	for i := 0; i < numOrigins; i++ {
+		origins = append(origins, &routingv8.GeoWaypoint{Lat: float64(i)})
+	}
+	destinations := make([]*routingv8.GeoWaypoint, 0, numDestinations)
+	for i := 0; i < numDestinations; i++ {
+		destinations = append(destinations, &routingv8.GeoWaypoint{Lat: float64(i)})
+	}
+	// Unreachable destination.
+	destinations[3].Lat = -1
+	httpClient := TiledMatrixMock{}
+	routingClient := routingv8.NewClient(&httpClient)
+
+	got, err := routingClient.Matrix.CalculateTiledMatrix(ctx, &routingv8.CalculateTiledMatrixRequest{
+		Request: &routingv8.CalculateMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:      origins,
+				Destinations: destinations,
+				RegionDefinition: routingv8.RegionDefinition{
+					Type: routingv8.RegionTypeWorld,
+				},
+			},
+		},
+		MaxOriginsPerTile:      3,
+		MaxDestinationsPerTile: 2,
+		MaxConcurrency:         2,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, httpClient.calls, 9)
+	assert.Assert(t, httpClient.maxInFlight <= 2)
+	assert.Equal(t, got.MatrixID, "")
+	assert.Equal(t, got.RegionDefinition.Type, routingv8.RegionType(routingv8.RegionTypeWorld))
+	assert.Equal(t, got.Matrix.NumOrigins, numOrigins)
+	assert.Equal(t, got.Matrix.NumDestinations, numDestinations)
+	assert.Assert(t, got.Matrix.Distances == nil)
+	for i := 0; i < numOrigins; i++ {
+		for j := 0; j < numDestinations; j++ {
+			k := i*numDestinations + j
+			if j == 3 {
+				assert.Equal(t, got.Matrix.ErrorCodes[k], routingv8.ErrorCode(routingv8.ErrorCodeDisconnected))
+				continue
+			}
+			assert.Equal(t, got.Matrix.ErrorCodes[k], routingv8.ErrorCode(routingv8.ErrorCodeSuccess))
+			assert.Equal(t, got.Matrix.TravelTimes[k], int32(i*1000+j))
+		}
+	}
+}
+
+func TestMatrixService_CalculateTiledMatrix_SingleTile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	httpClient := TiledMatrixMock{}
+	routingClient := routingv8.NewClient(&httpClient)
+
+	got, err := routingClient.Matrix.CalculateTiledMatrix(ctx, &routingv8.CalculateTiledMatrixRequest{
+		Request: &routingv8.CalculateMatrixRequest{
+			Body: &routingv8.CalculateMatrixBody{
+				Origins:      []*routingv8.GeoWaypoint{{Lat: 1}},
+				Destinations: []*routingv8.GeoWaypoint{{Lat: 2}},
+				RegionDefinition: routingv8.RegionDefinition{
+					Type: routingv8.RegionTypeWorld,
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, httpClient.calls, 1)
+	assert.Equal(t, got.MatrixID, "tile")
+	assert.DeepEqual(t, got.Matrix.TravelTimes, []int32{1002})
+}
+
+func TestMatrixService_CalculateTiledMatrix_WithoutDestinations(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name              string
+		maxOriginsPerTile int
+		expectedCalls     int
+	}{
+		{name: "single tile", expectedCalls: 1},
+		{name: "tiled", maxOriginsPerTile: 2, expectedCalls: 3},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			const numOrigins = 5
+			origins := make([]*routingv8.GeoWaypoint, 0, numOrigins)
+			for i := 0; i < numOrigins; i++ {
+				origins = append(origins, &routingv8.GeoWaypoint{Lat: float64(i)})
+			}
+			httpClient := TiledMatrixMock{}
+			routingClient := routingv8.NewClient(&httpClient)
+
+			// The origins are also the destinations.
+			got, err := routingClient.Matrix.CalculateTiledMatrix(
+				context.Background(),
+				&routingv8.CalculateTiledMatrixRequest{
+					Request: &routingv8.CalculateMatrixRequest{
+						Body: &routingv8.CalculateMatrixBody{
+							Origins: origins,
+							RegionDefinition: routingv8.RegionDefinition{
+								Type: routingv8.RegionTypeWorld,
+							},
+						},
+					},
+					MaxOriginsPerTile: tt.maxOriginsPerTile,
+				},
+			)
+			assert.NilError(t, err)
+			assert.Equal(t, httpClient.calls, tt.expectedCalls)
+			assert.Equal(t, got.Matrix.NumOrigins, numOrigins)
+			assert.Equal(t, got.Matrix.NumDestinations, numOrigins)
+			for i := 0; i < numOrigins; i++ {
+				for j := 0; j < numOrigins; j++ {
+					assert.Equal(t, got.Matrix.TravelTimes[i*numOrigins+j], int32(i*1000+j))
+				}
+			}
+		})
+	}
+}
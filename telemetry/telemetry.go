@@ -0,0 +1,112 @@
+// Package telemetry defines the hooks through which the HERE API clients report traces and metrics.
+//
+// The clients depend on no tracing or metrics library. Instead, the caller supplies a Tracer and Metrics, e.g.
+// thin adapters to OpenTelemetry, with the WithTracer and WithMetrics options of each client package:
+//
+//	client := routingv8.NewClient(
+//		routingv8.NewAPIKeyHTTPClient(apiKey, nil),
+//		routingv8.WithTracer(tracer),
+//		routingv8.WithMetrics(metrics),
+//	)
+//
+// Every service call, e.g. Routes or Geocoding, is reported as a span named after the operation. Each request it sends
+// is reported as a child span named after the HTTP method, and as a Measurement when its response body has been read.
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// Attribute keys reported by the clients.
+const (
+	// AttributeAPI is the HERE API of an operation, e.g. routingv8.
+	AttributeAPI = "here.api"
+	// AttributeTransportMode is the transport mode of a routing operation.
+	AttributeTransportMode = "here.transport_mode"
+	// AttributeWaypoints is the number of waypoints of a route, including origin and destination.
+	AttributeWaypoints = "here.waypoints"
+	// AttributeOrigins is the number of origins of a matrix.
+	AttributeOrigins = "here.origins"
+	// AttributeDestinations is the number of destinations of a matrix.
+	AttributeDestinations = "here.destinations"
+	// AttributeRecords is the number of records of a batch geocoder job.
+	AttributeRecords = "here.records"
+	// AttributeHTTPMethod is the HTTP method of a request.
+	AttributeHTTPMethod = "http.request.method"
+	// AttributeHTTPStatusCode is the HTTP status code of a response.
+	AttributeHTTPStatusCode = "http.response.status_code"
+	// AttributeRequestSize is the size of a request body in bytes.
+	AttributeRequestSize = "http.request.body.size"
+	// AttributeResponseSize is the size of a response body in bytes.
+	AttributeResponseSize = "http.response.body.size"
+)
+
+// Attribute is a key-value pair describing an operation. Values are strings, ints, int64s or bools.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an int attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 returns an int64 attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a span named after the operation, e.g. Routes, or the HTTP method of a request, as a child of any
+	// span in ctx. The returned context carries the span, and requests are sent with it, so that a tracing
+	// transport can propagate it.
+	Start(ctx context.Context, operation string, attributes ...Attribute) (context.Context, Span)
+}
+
+// Span is an operation in progress.
+type Span interface {
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attributes ...Attribute)
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+// Metrics records measurements of requests, e.g. into latency histograms and error counters.
+type Metrics interface {
+	// Record records the measurement of a completed request.
+	Record(ctx context.Context, measurement Measurement)
+}
+
+// Measurement of a request to the HERE API.
+type Measurement struct {
+	// Operation that sent the request, e.g. Routes.
+	Operation string
+	// Attributes of the operation, e.g. the transport mode, and of the response, e.g. its status code.
+	Attributes []Attribute
+	// Duration from sending the request until its response body was read, or it failed.
+	Duration time.Duration
+	// StatusCode of the response, or 0 if no response was received.
+	StatusCode int
+	// RequestSize is the size of the request body in bytes.
+	RequestSize int64
+	// ResponseSize is the number of response body bytes read.
+	ResponseSize int64
+	// Err is the error that prevented a response from being received, if any.
+	Err error
+}
+
+// Failed reports whether the request failed, either without a response or with a status code outside the 200
+// range. Failed measurements are typically counted as errors.
+func (m Measurement) Failed() bool {
+	return m.Err != nil || m.StatusCode < 200 || m.StatusCode > 299
+}
@@ -0,0 +1,232 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"go.einride.tech/here/geocodingsearchv7"
+	"go.einride.tech/here/heretest"
+	"go.einride.tech/here/telemetry"
+	"gotest.tools/v3/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name      string
+		attribute telemetry.Attribute
+		expected  telemetry.Attribute
+	}{
+		{
+			name:      "string",
+			attribute: telemetry.String(telemetry.AttributeTransportMode, "truck"),
+			expected:  telemetry.Attribute{Key: "here.transport_mode", Value: "truck"},
+		},
+		{
+			name:      "int",
+			attribute: telemetry.Int(telemetry.AttributeHTTPStatusCode, http.StatusOK),
+			expected:  telemetry.Attribute{Key: "http.response.status_code", Value: 200},
+		},
+		{
+			name:      "int64",
+			attribute: telemetry.Int64(telemetry.AttributeResponseSize, 1024),
+			expected:  telemetry.Attribute{Key: "http.response.body.size", Value: int64(1024)},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tt.attribute, tt.expected)
+		})
+	}
+}
+
+func TestMeasurement_Failed(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name        string
+		measurement telemetry.Measurement
+		expected    bool
+	}{
+		{name: "ok", measurement: telemetry.Measurement{StatusCode: http.StatusOK}},
+		{name: "accepted", measurement: telemetry.Measurement{StatusCode: http.StatusAccepted}},
+		{name: "redirect", measurement: telemetry.Measurement{StatusCode: http.StatusSeeOther}, expected: true},
+		{name: "not found", measurement: telemetry.Measurement{StatusCode: http.StatusNotFound}, expected: true},
+		{
+			name:        "transport error",
+			measurement: telemetry.Measurement{Err: errors.New("connection refused")},
+			expected:    true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.measurement.Failed(), tt.expected)
+		})
+	}
+}
+
+type spanKey struct{}
+
+type fakeSpan struct {
+	name       string
+	parent     *fakeSpan
+	attributes map[string]interface{}
+	errors     []error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttributes(attributes ...telemetry.Attribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *fakeSpan) End() {
+	s.ended = true
+}
+
+// fakeTracer is a telemetry.Tracer which keeps the spans it started, in start order.
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(
+	ctx context.Context,
+	operation string,
+	attributes ...telemetry.Attribute,
+) (context.Context, telemetry.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*fakeSpan)
+	span := &fakeSpan{name: operation, parent: parent, attributes: map[string]interface{}{}}
+	span.SetAttributes(attributes...)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// fakeMetrics is a telemetry.Metrics which keeps the measurements it recorded, and counts the failed ones.
+type fakeMetrics struct {
+	mu           sync.Mutex
+	measurements []telemetry.Measurement
+	errors       int
+}
+
+func (m *fakeMetrics) Record(_ context.Context, measurement telemetry.Measurement) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.measurements = append(m.measurements, measurement)
+	if measurement.Failed() {
+		m.errors++
+	}
+}
+
+func TestTracerAndMetrics(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{
+		Places: []heretest.Place{
+			{Title: "Einride", Position: geocodingsearchv7.GeoWaypoint{Lat: 59.33, Long: 18.06}},
+		},
+	})
+	t.Cleanup(server.Close)
+	var tracer fakeTracer
+	var metrics fakeMetrics
+	client := geocodingsearchv7.NewClient(
+		http.DefaultClient,
+		geocodingsearchv7.WithBaseURL(server.BaseURL()),
+		geocodingsearchv7.WithTracer(&tracer),
+		geocodingsearchv7.WithMetrics(&metrics),
+	)
+	ctx := context.Background()
+	q := "Einride"
+	_, err := client.Geocoding.Geocoding(ctx, &geocodingsearchv7.GeocodingRequest{Q: &q})
+	assert.NilError(t, err)
+	_, err = client.Lookup.Lookup(ctx, &geocodingsearchv7.LookupRequest{ID: "unknown"})
+	assert.ErrorContains(t, err, "404")
+
+	t.Run("spans", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, len(tracer.spans), 4)
+		geocoding, geocodingRequest := tracer.spans[0], tracer.spans[1]
+		assert.Equal(t, geocoding.name, "Geocoding")
+		assert.Assert(t, geocoding.parent == nil)
+		assert.DeepEqual(t, geocoding.attributes, map[string]interface{}{
+			telemetry.AttributeAPI: "geocodingsearchv7",
+		})
+		assert.Equal(t, len(geocoding.errors), 0)
+		assert.Assert(t, geocoding.ended)
+		assert.Equal(t, geocodingRequest.name, http.MethodGet)
+		assert.Equal(t, geocodingRequest.parent, geocoding)
+		assert.Equal(t, geocodingRequest.attributes[telemetry.AttributeHTTPMethod], http.MethodGet)
+		assert.Equal(t, geocodingRequest.attributes[telemetry.AttributeHTTPStatusCode], http.StatusOK)
+		assert.Equal(t, geocodingRequest.attributes[telemetry.AttributeRequestSize], int64(0))
+		assert.Assert(t, geocodingRequest.attributes[telemetry.AttributeResponseSize].(int64) > 0)
+		assert.Equal(t, len(geocodingRequest.errors), 0)
+		assert.Assert(t, geocodingRequest.ended)
+		lookup, lookupRequest := tracer.spans[2], tracer.spans[3]
+		assert.Equal(t, lookup.name, "Lookup")
+		assert.Equal(t, lookupRequest.parent, lookup)
+		assert.Equal(t, lookupRequest.attributes[telemetry.AttributeHTTPStatusCode], http.StatusNotFound)
+		// The failed request fails its service call as well.
+		assert.Equal(t, len(lookupRequest.errors), 1)
+		assert.Error(t, lookupRequest.errors[0], "status code 404")
+		assert.Equal(t, len(lookup.errors), 1)
+		assert.ErrorContains(t, lookup.errors[0], "404")
+		assert.Assert(t, lookup.ended && lookupRequest.ended)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, len(metrics.measurements), 2)
+		geocoding := metrics.measurements[0]
+		assert.Equal(t, geocoding.Operation, "Geocoding")
+		assert.Equal(t, geocoding.StatusCode, http.StatusOK)
+		assert.DeepEqual(t, geocoding.Attributes, []telemetry.Attribute{
+			telemetry.String(telemetry.AttributeAPI, "geocodingsearchv7"),
+			telemetry.String(telemetry.AttributeHTTPMethod, http.MethodGet),
+			telemetry.Int(telemetry.AttributeHTTPStatusCode, http.StatusOK),
+		})
+		assert.Assert(t, geocoding.Duration > 0)
+		assert.Assert(t, geocoding.ResponseSize > 0)
+		lookup := metrics.measurements[1]
+		assert.Equal(t, lookup.Operation, "Lookup")
+		assert.Equal(t, lookup.StatusCode, http.StatusNotFound)
+		assert.Equal(t, metrics.errors, 1)
+	})
+}
+
+func TestTracerAndMetrics_TransportError(t *testing.T) {
+	t.Parallel()
+	server := heretest.NewServer(heretest.Config{})
+	// Requests to the closed server fail without a response.
+	server.Close()
+	var tracer fakeTracer
+	var metrics fakeMetrics
+	client := geocodingsearchv7.NewClient(
+		http.DefaultClient,
+		geocodingsearchv7.WithBaseURL(server.BaseURL()),
+		geocodingsearchv7.WithTracer(&tracer),
+		geocodingsearchv7.WithMetrics(&metrics),
+	)
+	_, err := client.Lookup.Lookup(context.Background(), &geocodingsearchv7.LookupRequest{ID: "here:pds:place:1"})
+	assert.Assert(t, err != nil)
+	assert.Equal(t, len(tracer.spans), 2)
+	for _, span := range tracer.spans {
+		assert.Equal(t, len(span.errors), 1)
+		assert.Assert(t, span.ended)
+	}
+	_, hasStatusCode := tracer.spans[1].attributes[telemetry.AttributeHTTPStatusCode]
+	assert.Assert(t, !hasStatusCode)
+	assert.Equal(t, len(metrics.measurements), 1)
+	assert.Equal(t, metrics.measurements[0].StatusCode, 0)
+	assert.Assert(t, metrics.measurements[0].Err != nil)
+	assert.Equal(t, metrics.errors, 1)
+}